@@ -1,5 +1,5 @@
 module github.com/calvine/simplevalidation
 
-go 1.16
+go 1.20
 
 require github.com/google/uuid v1.2.0
@@ -0,0 +1,278 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+const (
+	checkNotStructErrorTemplate     = "Check requires a struct type, but the type is %s"
+	checkNilTypeErrorTemplate       = "Check requires a struct type, but the type is nil"
+	checkArrayDepthErrorTemplate    = "the tag data has %d [] pairs but the field type %s is not a slice or array at depth %d"
+	checkMapTypeErrorTemplate       = "map tag data requires a map, but the field type is %s"
+	checkStructTypeErrorTemplate    = "the struct validator requires a struct, but the field type is %s"
+	checkUnknownOptionErrorTemplate = "unknown option %q for the %s validator"
+	checkMissingValueErrorTemplate  = "option %q of the %s validator requires a value"
+	checkFlagValueErrorTemplate     = "option %q of the %s validator is a flag and does not take a value"
+	checkNullableErrorTemplate      = "the nullable option requires a pointer, but the field type is %s"
+	checkRequiredZeroErrorTemplate  = "option %q of the %s validator allows the zero value, which the required option rejects"
+)
+
+var (
+	// engineOptions contains the options read by the validation package itself, which validators do not have to declare.
+	engineOptions = []validator.OptionSpec{
+		{Key: requiredOption, Description: "the value must not be empty", Flag: true},
+		{Key: omitEmptyOption, Description: "the value is not validated when it is empty", Flag: true},
+		{Key: nullableOption, Description: "a nil pointer is valid even when the value is required", Flag: true},
+	}
+)
+
+/*
+	CheckError contains every problem found in the tag data of a struct type by Check.
+*/
+type CheckError struct {
+	// DataType is the name of the struct type that was checked.
+	DataType string
+	// Errors contains a *validator.FieldError with the bad tag code for each problem found, in the order the fields were checked.
+	Errors []error
+}
+
+// Error produces a string that lists every problem found in the tag data.
+func (ce *CheckError) Error() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("tag data check failed for %s:", ce.DataType))
+	for _, err := range ce.Errors {
+		builder.WriteString("\n\t- ")
+		builder.WriteString(err.Error())
+	}
+	return builder.String()
+}
+
+// Unwrap returns every problem found in the tag data, so errors.Is(err, validator.ErrBadTag) is true for a CheckError.
+func (ce *CheckError) Unwrap() []error {
+	return ce.Errors
+}
+
+/*
+	Check compiles the tag data of a struct type, and of every struct type reached from it with the "struct" validator name, without validating a value.
+
+	It returns a *CheckError reporting:
+		- validator names that are not registered with the Engine.
+		- options that cannot be read, like min=abc, and syntax errors in the tag data.
+		- option keys that the validator does not accept, for validators that implement validator.OptionDescriber.
+		- the nullable option on a field that is not a pointer, since only a nil pointer is null.
+		- the required option along with an option that allows the zero value, like uuid,required,allowemptyuuid, since required always rejects the zero value.
+		- [] pairs in the tag data that do not match the slices and arrays of the field type.
+		- validators that cannot validate the field type, for validators that implement validator.TypeChecker, like email on an int.
+		- cross field and conditional rules that reference fields that do not exist.
+
+	The tag data of every validation group is checked. Check is meant to be called once at startup or in unit tests, so problems with tag data are found before any value is validated.
+*/
+func (e *Engine) Check(structType reflect.Type) error {
+	c := &tagChecker{
+		engine:  e,
+		visited: map[reflect.Type]bool{},
+	}
+	if structType == nil {
+		// reflect.TypeOf returns nil for a nil interface, which has no type to check.
+		return &CheckError{
+			DataType: "nil",
+			Errors:   []error{validator.NewFieldError("", validator.CodeBadTag, "value", "", nil, "").WithDetail("error", checkNilTypeErrorTemplate)},
+		}
+	}
+	structType = derefType(structType)
+	if structType.Kind() != reflect.Struct {
+		c.errs = append(c.errs, validator.NewFieldError("", validator.CodeBadTag, "value", "", nil, "").WithDetail("error", fmt.Sprintf(checkNotStructErrorTemplate, structType.String())))
+	} else {
+		c.checkStruct(structType, "")
+	}
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return &CheckError{
+		DataType: structType.String(),
+		Errors:   c.errs,
+	}
+}
+
+// MustCompile checks the tag data of the type of the value with Check and compiles its plan for the default validation group, so the first validation does not have to.
+// It panics with the *CheckError when the tag data has problems, so it can be used in init functions and package level variables.
+func (e *Engine) MustCompile(v interface{}) {
+	structType := reflect.TypeOf(v)
+	if err := e.Check(structType); err != nil {
+		panic(err)
+	}
+	e.getStructPlan(derefType(structType), defaultGroups)
+}
+
+// tagChecker walks a struct type for Check, collecting the problems found in its tag data.
+type tagChecker struct {
+	engine *Engine
+	// visited contains the struct types that have been checked, so recursive types are only checked once.
+	visited map[reflect.Type]bool
+	errs    []error
+}
+
+// checkStruct checks the tag data of every field of the struct type. The path is the name of the struct in the errors, which is empty for the top level struct.
+func (c *tagChecker) checkStruct(structType reflect.Type, path string) {
+	if c.visited[structType] {
+		return
+	}
+	c.visited[structType] = true
+	plan := c.engine.compileStructPlan(structType, allGroups)
+	for i := range plan.fields {
+		field := &plan.fields[i]
+		name := field.name
+		if path != "" {
+			name = path + "." + field.name
+		}
+		for _, err := range field.compileErrors() {
+			c.errs = append(c.errs, withFieldPath(err, name))
+		}
+		c.checkField(field, structType.Field(field.index).Type, name)
+	}
+}
+
+// checkField checks that the compiled tag data of a field matches the field type.
+func (c *tagChecker) checkField(field *fieldPlan, fieldType reflect.Type, name string) {
+	if field.nullable && fieldType.Kind() != reflect.Ptr && fieldType.Kind() != reflect.Interface {
+		c.addError(field.validatorName, name, fmt.Sprintf(checkNullableErrorTemplate, fieldType.String()))
+	}
+	fieldType = derefType(fieldType)
+	if fieldType.Kind() == reflect.Interface {
+		// the type of the value is only known when a value is validated.
+		return
+	}
+	if field.isMap {
+		if fieldType.Kind() != reflect.Map {
+			c.addError(mapValidatorName, name, fmt.Sprintf(checkMapTypeErrorTemplate, fieldType.String()))
+			return
+		}
+		if field.mapKeys != nil {
+			c.checkField(field.mapKeys, fieldType.Key(), name)
+		}
+		if field.mapValues != nil {
+			c.checkField(field.mapValues, fieldType.Elem(), name)
+		}
+		return
+	}
+	if field.err != nil && field.fieldValidator == nil {
+		// the validator could not be built, which is already reported by the compile errors.
+		return
+	}
+	for depth := 1; depth <= int(field.arrayDepth); depth++ {
+		if fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array {
+			c.addError(field.validatorName, name, fmt.Sprintf(checkArrayDepthErrorTemplate, field.arrayDepth, fieldType.String(), depth))
+			return
+		}
+		fieldType = derefType(fieldType.Elem())
+		name += "[]"
+	}
+	if fieldType.Kind() == reflect.Interface {
+		return
+	}
+	if field.fieldValidator == nil {
+		if fieldType.Kind() != reflect.Struct {
+			c.addError(structValidatorName, name, fmt.Sprintf(checkStructTypeErrorTemplate, fieldType.String()))
+			return
+		}
+		c.checkStruct(fieldType, name)
+		return
+	}
+	if !c.engine.strictOptions {
+		// with strict options these errors are already part of the compile errors.
+		for _, err := range checkOptions(field.fieldValidator, field.validatorName, field.options) {
+			c.addError(field.validatorName, name, err.Error())
+		}
+	}
+	if field.required && field.arrayDepth == 0 {
+		// with [] in the tag data required applies to the slice, and the options apply to its elements.
+		for _, err := range checkRequiredOptions(field.fieldValidator, field.validatorName, field.options) {
+			c.addError(field.validatorName, name, err.Error())
+		}
+	}
+	if typeChecker, ok := field.fieldValidator.(validator.TypeChecker); ok {
+		if err := typeChecker.CheckType(fieldType); err != nil {
+			c.addError(field.validatorName, name, err.Error())
+		}
+	}
+}
+
+/*
+	checkOptions returns an error for each tag item that is not an option the validator accepts, along with the options read by the validation package.
+
+	An option is not accepted when its key is not declared, when it is a flag option that has a value, like required=false, or when it requires a value and has none.
+	No errors are returned when the validator does not implement validator.OptionDescriber, since its options are not known.
+*/
+func checkOptions(fieldValidator validator.Validator, validatorName string, items []string) []error {
+	describer, ok := fieldValidator.(validator.OptionDescriber)
+	if !ok {
+		return nil
+	}
+	specs := map[string]validator.OptionSpec{}
+	for _, spec := range append(describer.Options(), engineOptions...) {
+		specs[spec.Key] = spec
+	}
+	errs := []error{}
+	for _, item := range items {
+		// the items were checked when they were split, so the option can always be read.
+		option, _ := validator.ParseOption(item)
+		spec, ok := specs[option.Key]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf(checkUnknownOptionErrorTemplate, option.Key, validatorName))
+		case spec.Flag && option.HasValue:
+			errs = append(errs, fmt.Errorf(checkFlagValueErrorTemplate, option.Key, validatorName))
+		case !spec.Flag && !option.HasValue:
+			errs = append(errs, fmt.Errorf(checkMissingValueErrorTemplate, option.Key, validatorName))
+		}
+	}
+	return errs
+}
+
+// checkRequiredOptions returns an error for each tag item that is an option allowing the zero value, which contradicts the required option of the field.
+func checkRequiredOptions(fieldValidator validator.Validator, validatorName string, items []string) []error {
+	describer, ok := fieldValidator.(validator.OptionDescriber)
+	if !ok {
+		return nil
+	}
+	allowsZero := map[string]bool{}
+	for _, spec := range describer.Options() {
+		allowsZero[spec.Key] = spec.AllowsZero
+	}
+	errs := []error{}
+	for _, item := range items {
+		// the items were checked when they were split, so the option can always be read.
+		option, _ := validator.ParseOption(item)
+		if allowsZero[option.Key] {
+			errs = append(errs, fmt.Errorf(checkRequiredZeroErrorTemplate, option.Key, validatorName))
+		}
+	}
+	return errs
+}
+
+// addError adds a bad tag error for the field to the problems found.
+func (c *tagChecker) addError(validatorName, name, message string) {
+	c.errs = append(c.errs, validator.NewFieldError(validatorName, validator.CodeBadTag, name, "", nil, "").WithDetail("error", message))
+}
+
+// derefType returns the type a pointer type points to, through any number of pointers.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// withFieldPath returns a *validator.FieldError named after the path to the field from the checked struct type.
+func withFieldPath(err error, name string) error {
+	if fieldError, ok := err.(*validator.FieldError); ok {
+		namedError := *fieldError
+		namedError.Field = name
+		return &namedError
+	}
+	return err
+}
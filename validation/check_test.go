@@ -0,0 +1,210 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
+	"github.com/google/uuid"
+)
+
+type CheckedDetail struct {
+	Email int `validate:"email"`
+}
+
+type CheckedItem struct {
+	Name     string            `validate:"strng,required"`
+	Age      int               `validate:"int,mn=3,min=abc"`
+	Tags     string            `validate:"[]string,max=10"`
+	Detail   *CheckedDetail    `validate:"struct"`
+	Details  []CheckedDetail   `validate:"[]struct"`
+	Limits   map[string]string `validate:"keys=string;values=int"`
+	Password string            `validate:"string,required,min=8" groups:"create"`
+	Confirm  string            `validate:"string,eqfield=Pasword"`
+	Anything interface{}       `validate:"string,min=3"`
+}
+
+func TestCheck(t *testing.T) {
+	err := Check(reflect.TypeOf(&CheckedItem{}))
+	var checkError *CheckError
+	if !errors.As(err, &checkError) {
+		t.Fatalf("err should be a *CheckError: %v", err)
+	}
+	if !errors.Is(err, validator.ErrBadTag) {
+		t.Error("a CheckError should wrap validator.ErrBadTag")
+	}
+	expected := []string{"Name", "Age", "Age", "Tags", "Detail.Email", "Limits", "Confirm"}
+	fields := []string{}
+	for _, fieldError := range checkError.Errors {
+		fields = append(fields, fieldError.(*validator.FieldError).Field)
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("the errors should be for the fields %v but were for %v:\n%v", expected, fields, err)
+	}
+}
+
+func TestCheckValidTagData(t *testing.T) {
+	for _, value := range []interface{}{TestStruct{}, Booking{}, MapItem{}, AddressBook{}, Account{}, Order{}} {
+		if err := Check(reflect.TypeOf(value)); err != nil {
+			t.Errorf("%T should have valid tag data: %v", value, err)
+		}
+	}
+}
+
+func TestCheckTypeOptions(t *testing.T) {
+	testValue := struct {
+		Created int64  `validate:"time"`
+		Updated int64  `validate:"time,allowint"`
+		ID      string `validate:"uuid,allowstring"`
+	}{}
+	err := Check(reflect.TypeOf(testValue))
+	var checkError *CheckError
+	if !errors.As(err, &checkError) || len(checkError.Errors) != 1 || checkError.Errors[0].(*validator.FieldError).Field != "Created" {
+		t.Errorf("only Created should have an error, since allowint allows an int64: %v", err)
+	}
+}
+
+func TestCheckNotStruct(t *testing.T) {
+	if err := Check(reflect.TypeOf(3)); !errors.Is(err, validator.ErrBadTag) {
+		t.Errorf("Check should return an error for a type that is not a struct: %v", err)
+	}
+}
+
+func TestMustCompile(t *testing.T) {
+	e := New()
+	e.MustCompile(&TestStruct{})
+	planCache := e.planCache.Load().(*sync.Map)
+	if _, ok := planCache.Load(planKey{structType: reflect.TypeOf(TestStruct{}), groups: defaultGroups.key()}); !ok {
+		t.Error("MustCompile should cache the plan for the default group")
+	}
+	defer func() {
+		if recovered := recover(); recovered == nil {
+			t.Error("MustCompile should panic when the tag data has problems")
+		}
+	}()
+	e.MustCompile(CheckedItem{})
+}
+
+func TestCheckNil(t *testing.T) {
+	var checkError *CheckError
+	if err := Check(reflect.TypeOf(nil)); !errors.As(err, &checkError) {
+		t.Errorf("Check of a nil type should return a *CheckError: %v", err)
+	}
+	defer func() {
+		recovered := recover()
+		if err, ok := recovered.(error); !ok || !errors.As(err, &checkError) {
+			t.Errorf("MustCompile of nil should panic with a *CheckError: %v", recovered)
+		}
+	}()
+	MustCompile(nil)
+}
+
+func TestStrictOptions(t *testing.T) {
+	testValue := struct {
+		Name    string `validate:"string,minimum=3"`
+		Age     int    `validate:"int,min"`
+		ID      string `validate:"string,required=false"`
+		Created int64  `validate:"time,allowint=true"`
+		Email   string `validate:"email,required,checkdomainmx"`
+	}{Email: "a"}
+	validationError := New(WithStrictOptions()).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	badTagFields := []string{}
+	for _, fieldError := range validationError.FieldErrors() {
+		if fieldError.Code == validator.CodeBadTag {
+			badTagFields = append(badTagFields, fieldError.Field)
+		}
+	}
+	if expected := []string{"Name", "Age", "ID", "Created"}; !reflect.DeepEqual(badTagFields, expected) {
+		t.Errorf("the bad tag errors should be for %v but were for %v", expected, badTagFields)
+	}
+	if errs := validationError.Errors["Email"]; len(errs) != 1 || errors.Is(errs[0], validator.ErrBadTag) {
+		t.Errorf("Email should have valid tag data and fail validation: %v", errs)
+	}
+	for _, fieldError := range ValidateStructWithTag(testValue).FieldErrors() {
+		if fieldError.Field == "Name" || fieldError.Field == "Created" {
+			t.Errorf("%s should not have an error without strict options: %v", fieldError.Field, fieldError)
+		}
+	}
+}
+
+func TestCheckStrictOptions(t *testing.T) {
+	testValue := struct {
+		Name string `validate:"string,minimum=3"`
+		ID   string `validate:"string,required=false"`
+	}{}
+	for _, e := range []*Engine{New(), New(WithStrictOptions())} {
+		var checkError *CheckError
+		if err := e.Check(reflect.TypeOf(testValue)); !errors.As(err, &checkError) || len(checkError.Errors) != 2 {
+			t.Errorf("Check should report each bad option once, strict options %t: %v", e.strictOptions, err)
+		}
+	}
+}
+
+func TestCheckNullable(t *testing.T) {
+	testValue := struct {
+		Name  string  `validate:"string,nullable"`
+		Note  *string `validate:"string,required,nullable"`
+		Codes []int   `validate:"[]int,nullable"`
+	}{}
+	err := Check(reflect.TypeOf(testValue))
+	var checkError *CheckError
+	if !errors.As(err, &checkError) {
+		t.Fatalf("err should be a *CheckError: %v", err)
+	}
+	fields := []string{}
+	for _, fieldError := range checkError.Errors {
+		fields = append(fields, fieldError.(*validator.FieldError).Field)
+	}
+	if expected := []string{"Name", "Codes"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("nullable should only be reported for the fields %v but was for %v:\n%v", expected, fields, err)
+	}
+}
+
+func TestCheckEmptyValidatorName(t *testing.T) {
+	testValue := struct {
+		Matrix [][]int `validate:"[][]"`
+		Codes  []int   `validate:"[]"`
+		Name   string  `validate:",required"`
+	}{}
+	err := Check(reflect.TypeOf(testValue))
+	var checkError *CheckError
+	if !errors.As(err, &checkError) {
+		t.Fatalf("err should be a *CheckError: %v", err)
+	}
+	fields := []string{}
+	for _, fieldError := range checkError.Errors {
+		fields = append(fields, fieldError.(*validator.FieldError).Field)
+	}
+	if expected := []string{"Matrix", "Codes", "Name"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("the missing validator name should be reported for the fields %v but was for %v:\n%v", expected, fields, err)
+	}
+	if validationError := ValidateStructWithTag(testValue); validationError == nil || !errors.Is(validationError, validator.ErrBadTag) {
+		t.Errorf("validating a value with no validator name in its tag data should be a bad tag error: %v", validationError)
+	}
+}
+
+func TestCheckRequiredAllowsZero(t *testing.T) {
+	testValue := struct {
+		ID     uuid.UUID   `validate:"uuid,required,allowemptyuuid"`
+		Other  uuid.UUID   `validate:"uuid,allowemptyuuid"`
+		IDs    []uuid.UUID `validate:"[]uuid,required,allowemptyuuid"`
+		Parent uuid.UUID   `validate:"uuid,required"`
+	}{}
+	err := Check(reflect.TypeOf(testValue))
+	var checkError *CheckError
+	if !errors.As(err, &checkError) {
+		t.Fatalf("err should be a *CheckError: %v", err)
+	}
+	fields := []string{}
+	for _, fieldError := range checkError.Errors {
+		fields = append(fields, fieldError.(*validator.FieldError).Field)
+	}
+	if expected := []string{"ID"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("required with allowemptyuuid should only be reported for the fields %v but was for %v:\n%v", expected, fields, err)
+	}
+}
@@ -0,0 +1,187 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+const (
+	// collectionValidatorName is the validator name used in the errors produced by collection rules.
+	collectionValidatorName = "collection"
+
+	collectionRuleValueErrorTemplate = "collection tag %s value invalid: %s"
+	uniqueFieldErrorTemplate         = "unique=%s requires elements that are structs with a field named %s, but the element is of type %s"
+)
+
+/*
+	collectionRules are the rules evaluated on a slice, array or map itself, before its elements are validated.
+
+	They are read from the tag data along with the validator parameters, and are evaluated at each [] depth:
+
+		`validate:"[]int,minlen=1,maxlen=10,unique,min=0"`
+
+	In tag data without [] pairs the keys are not collection rules, they are left for the validator to read as its own options, so a validator can accept len=5 for a scalar value.
+
+	The supported rules are:
+
+		len=N         the collection must have exactly N elements
+		minlen=N      the collection must have at least N elements
+		maxlen=N      the collection must have at most N elements
+		notempty      the collection must have at least one element
+		unique        the elements of the collection must not repeat, for a map its values must not repeat
+		unique=Field  the Field of each struct element must not repeat
+*/
+type collectionRules struct {
+	Len      *int
+	MinLen   *int
+	MaxLen   *int
+	NotEmpty bool
+	Unique   bool
+	// UniqueField is the name of the struct field compared when Unique is true. When it is empty the elements themselves are compared.
+	UniqueField string
+}
+
+// extractCollectionRules removes the collection rules from the tag items, returning the remaining items for the validator and the rules that were found.
+func extractCollectionRules(items []string) ([]string, collectionRules, error) {
+	rules := collectionRules{}
+	validatorItems := make([]string, 0, len(items))
+	for _, item := range items {
+		option, err := validator.ParseOption(item)
+		if err != nil {
+			return validatorItems, rules, err
+		}
+		switch option.Key {
+		case "len", "minlen", "maxlen":
+			if !option.HasValue {
+				return validatorItems, rules, fmt.Errorf(collectionRuleValueErrorTemplate, option.Key, "no value provided")
+			}
+			length, err := strconv.Atoi(option.Value)
+			if err != nil {
+				return validatorItems, rules, fmt.Errorf(collectionRuleValueErrorTemplate, option.Key, err.Error())
+			}
+			switch option.Key {
+			case "len":
+				rules.Len = &length
+			case "minlen":
+				rules.MinLen = &length
+			default:
+				rules.MaxLen = &length
+			}
+		case "notempty":
+			rules.NotEmpty = true
+		case "unique":
+			rules.Unique = true
+			rules.UniqueField = option.Value
+		default:
+			validatorItems = append(validatorItems, item)
+		}
+	}
+	return validatorItems, rules, nil
+}
+
+// check evaluates the collection rules on a slice, array or map and returns an error for each rule that fails.
+func (cr collectionRules) check(value reflect.Value, fieldName string) []error {
+	errs := []error{}
+	length := value.Len()
+	lengthDetail := strconv.Itoa(length)
+	if cr.NotEmpty && length == 0 {
+		errs = append(errs, validator.NewFieldError(collectionValidatorName, validator.CodeRequired, fieldName, "", nil, "").WithKey("collection.notempty"))
+	}
+	if cr.Len != nil && length != *cr.Len {
+		errs = append(errs, validator.NewFieldError(collectionValidatorName, validator.CodeLen, fieldName, strconv.Itoa(*cr.Len), nil, "").WithDetail("length", lengthDetail))
+	}
+	if cr.MinLen != nil && length < *cr.MinLen {
+		errs = append(errs, validator.NewFieldError(collectionValidatorName, validator.CodeMin, fieldName, strconv.Itoa(*cr.MinLen), nil, "").WithDetail("length", lengthDetail))
+	}
+	if cr.MaxLen != nil && length > *cr.MaxLen {
+		errs = append(errs, validator.NewFieldError(collectionValidatorName, validator.CodeMax, fieldName, strconv.Itoa(*cr.MaxLen), nil, "").WithDetail("length", lengthDetail))
+	}
+	if cr.Unique {
+		if err := cr.checkUnique(value, fieldName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+/*
+	checkUnique returns an error for the first element of the collection that repeats an earlier element, or nil when every element is unique.
+
+	Comparable values are looked up in a map, so large collections are checked in linear time, and only the values that cannot be map keys, like slices, are compared with reflect.DeepEqual.
+	Nil elements have no value to compare, so they are skipped.
+*/
+func (cr collectionRules) checkUnique(value reflect.Value, fieldName string) error {
+	elements := make([]reflect.Value, 0, value.Len())
+	labels := make([]string, 0, value.Len())
+	if value.Kind() == reflect.Map {
+		for _, key := range sortedMapKeys(value) {
+			elements = append(elements, value.MapIndex(key))
+			labels = append(labels, fmt.Sprint(key.Interface()))
+		}
+	} else {
+		for i := 0; i < value.Len(); i++ {
+			elements = append(elements, value.Index(i))
+			labels = append(labels, strconv.Itoa(i))
+		}
+	}
+	seen := make(map[interface{}]bool, len(elements))
+	uncomparable := []interface{}{}
+	for i, element := range elements {
+		compared, ok, err := cr.uniqueValue(element, fieldName)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		repeated := false
+		if reflect.ValueOf(compared).Comparable() {
+			repeated = seen[compared]
+			seen[compared] = true
+		} else {
+			for _, previous := range uncomparable {
+				if reflect.DeepEqual(previous, compared) {
+					repeated = true
+					break
+				}
+			}
+			uncomparable = append(uncomparable, compared)
+		}
+		if repeated {
+			return validator.NewFieldError(collectionValidatorName, validator.CodeUnique, fieldName, cr.UniqueField, compared, "").WithDetail("index", labels[i])
+		}
+	}
+	return nil
+}
+
+// uniqueValue returns the value of an element compared by the unique rule, which is the UniqueField of a struct element when it is set.
+// It returns false when the element is nil, or the UniqueField is read through a nil embedded struct pointer, since there is no value to compare.
+func (cr collectionRules) uniqueValue(element reflect.Value, fieldName string) (interface{}, bool, error) {
+	element, ok := derefValue(element)
+	if !ok {
+		return nil, false, nil
+	}
+	if cr.UniqueField == "" {
+		return element.Interface(), true, nil
+	}
+	if element.Kind() == reflect.Struct {
+		if field, found := element.Type().FieldByName(cr.UniqueField); found {
+			structField, err := element.FieldByIndexErr(field.Index)
+			if err != nil {
+				return nil, false, nil
+			}
+			if structField.CanInterface() {
+				return structField.Interface(), true, nil
+			}
+		}
+	}
+	return nil, false, validator.NewFieldError(collectionValidatorName, validator.CodeBadTag, fieldName, "unique="+cr.UniqueField, nil, "").WithDetail("error", fmt.Sprintf(uniqueFieldErrorTemplate, cr.UniqueField, cr.UniqueField, element.Type().String()))
+}
+
+// isEmpty returns true when there are no collection rules.
+func (cr collectionRules) isEmpty() bool {
+	return cr.Len == nil && cr.MinLen == nil && cr.MaxLen == nil && !cr.NotEmpty && !cr.Unique
+}
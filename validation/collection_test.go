@@ -0,0 +1,175 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+type CollectionItem struct {
+	Tags      []string       `validate:"[]string,minlen=1,maxlen=3,unique,max=5"`
+	Matrix    [][]int        `validate:"[][]int,len=2,max=10"`
+	Addresses []Address      `validate:"[]struct,notempty,unique=City"`
+	Limits    map[string]int `validate:"map,maxlen=1,unique;values=int,min=0"`
+	Codes     []int          `validate:"[]int,notempty"`
+}
+
+func TestCollectionRules(t *testing.T) {
+	testValue := CollectionItem{
+		Tags:   []string{"a", "b", "a", "toolong"},
+		Matrix: [][]int{{1, 2}, {3}},
+		Addresses: []Address{
+			{City: "Orlando", PostalCode: "32801"},
+			{City: "Orlando", PostalCode: "32802"},
+		},
+		Limits: map[string]int{"a": 1, "b": 1},
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	expected := []string{"Tags", "Tags[3]", "Matrix[1]", "Addresses", "Limits", "Codes"}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("the error keys should be %v but were %v", expected, keys)
+	}
+	expectedErrors := map[string][]error{
+		"Tags":      {validator.ErrMax, validator.ErrUnique},
+		"Matrix[1]": {validator.ErrLen},
+		"Addresses": {validator.ErrUnique},
+		"Limits":    {validator.ErrMax, validator.ErrUnique},
+		"Codes":     {validator.ErrRequired},
+	}
+	for key, sentinels := range expectedErrors {
+		errs := validationError.Errors[key]
+		if len(errs) != len(sentinels) {
+			t.Errorf("%s should have %d errors but had %d: %v", key, len(sentinels), len(errs), errs)
+			continue
+		}
+		for i, sentinel := range sentinels {
+			if !errors.Is(errs[i], sentinel) {
+				t.Errorf("%s error %d should be %v but was %v", key, i, sentinel, errs[i])
+			}
+		}
+	}
+}
+
+func TestCollectionRulesValid(t *testing.T) {
+	testValue := CollectionItem{
+		Tags:   []string{"a", "b"},
+		Matrix: [][]int{{1, 2}, {3, 4}},
+		Addresses: []Address{
+			{City: "Orlando", PostalCode: "32801"},
+			{City: "Tampa", PostalCode: "33601"},
+		},
+		Codes: []int{1},
+	}
+	if validationError := ValidateStructWithTag(testValue); validationError != nil {
+		t.Error("testValue should have passed validation", validationError.Error())
+	}
+}
+
+func TestCollectionRuleTagErrors(t *testing.T) {
+	testValue := struct {
+		Tags  []string `validate:"[]string,minlen=abc"`
+		Items []int    `validate:"[]int,unique=ID"`
+	}{Items: []int{1, 2}}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	if !errors.Is(validationError.Errors["value"][0], validator.ErrBadTag) {
+		t.Error("an invalid minlen should be a bad tag error", validationError.Error())
+	}
+	if !errors.Is(validationError.Errors["Items"][0], validator.ErrBadTag) {
+		t.Error("unique=ID on a slice of ints should be a bad tag error", validationError.Error())
+	}
+}
+
+// lengthValidator is a custom validator with its own len option, which is not a collection rule without [] in the tag data.
+type lengthValidator struct {
+	length int
+}
+
+func (lv *lengthValidator) Validate(n interface{}, fieldName string, fieldKind reflect.Kind) (bool, error) {
+	if value, ok := n.(string); !ok || len(value) != lv.length {
+		return false, validator.NewFieldError("length", validator.CodeLen, fieldName, strconv.Itoa(lv.length), n, "")
+	}
+	return true, nil
+}
+
+func (lv *lengthValidator) ReadOptionsFromTagItems(items []string) error {
+	for _, item := range items {
+		if option, err := validator.ParseOption(item); err == nil && option.Key == "len" {
+			length, err := strconv.Atoi(option.Value)
+			if err != nil {
+				return err
+			}
+			lv.length = length
+		}
+	}
+	return nil
+}
+
+func TestCollectionRulesWithoutDepth(t *testing.T) {
+	engine := New(WithValidator("length", func() validator.Validator { return &lengthValidator{} }))
+	testValue := struct {
+		Code  string   `validate:"length,len=5"`
+		Codes []string `validate:"[]string,len=2"`
+	}{Code: "abcde", Codes: []string{"abcde", "fghij"}}
+	if validationError := engine.ValidateStructWithTag(testValue); validationError != nil {
+		t.Errorf("len should be read by the validator without [] in the tag data, and be a collection rule with []: %v", validationError)
+	}
+	testValue.Code = "abc"
+	validationError := engine.ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("Code should fail the len option of the validator")
+	}
+	if errs := validationError.Errors["Code"]; len(errs) != 1 || !errors.Is(errs[0], validator.ErrLen) {
+		t.Errorf("Code should have a single len error from the validator: %v", errs)
+	}
+	unknownOptions := struct {
+		Name string `validate:"string,minlen=3,unique"`
+	}{}
+	if err := Check(reflect.TypeOf(unknownOptions)); err == nil || !errors.Is(err, validator.ErrBadTag) {
+		t.Errorf("Check should report the options the string validator does not accept: %v", err)
+	}
+	if validationError := New(WithStrictOptions()).ValidateStructWithTag(unknownOptions); validationError == nil || !errors.Is(validationError, validator.ErrBadTag) {
+		t.Errorf("strict options should reject the options the string validator does not accept: %v", validationError)
+	}
+}
+
+func TestCollectionUnique(t *testing.T) {
+	one, otherOne, two := 1, 1, 2
+	testCases := []struct {
+		name     string
+		value    interface{}
+		repeated bool
+	}{
+		{name: "nil pointers", value: []*int{nil, nil}},
+		{name: "nil pointers and values", value: []*int{nil, &one, nil, &two}},
+		{name: "repeated pointer values", value: []*int{nil, &one, &otherOne}, repeated: true},
+		{name: "nil interfaces", value: []interface{}{nil, 1, nil, "1"}},
+		{name: "uncomparable values", value: [][]int{{1}, {1, 2}}},
+		{name: "repeated uncomparable values", value: [][]int{{1, 2}, {1}, {1, 2}}, repeated: true},
+		{name: "mixed values", value: []interface{}{1, []int{1}, "a", []int{1}}, repeated: true},
+		{name: "large collection", value: func() []int {
+			values := make([]int, 100000)
+			for i := range values {
+				values[i] = i
+			}
+			return values
+		}()},
+	}
+	rules := collectionRules{Unique: true}
+	for _, testCase := range testCases {
+		err := rules.checkUnique(reflect.ValueOf(testCase.value), "Values")
+		if repeated := err != nil; repeated != testCase.repeated {
+			t.Errorf("%s: unique should report a repeated value %t, but the error was %v", testCase.name, testCase.repeated, err)
+		} else if repeated && !errors.Is(err, validator.ErrUnique) {
+			t.Errorf("%s: the error should wrap validator.ErrUnique: %v", testCase.name, err)
+		}
+	}
+}
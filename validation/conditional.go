@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+const (
+	requiredIfRule      = "required_if"
+	requiredUnlessRule  = "required_unless"
+	requiredWithRule    = "required_with"
+	requiredWithoutRule = "required_without"
+	excludedIfRule      = "excluded_if"
+
+	conditionalPairsErrorTemplate  = "%s requires pairs of a field name and a value"
+	conditionalFieldsErrorTemplate = "%s requires at least one field name"
+)
+
+/*
+	conditionalRule makes a field required, or requires it to be empty, depending on the values of other fields.
+
+	The rules are:
+
+		required_if=Field value [Field value]...      the field is required when every Field has its value
+		required_unless=Field value [Field value]...  the field is required unless every Field has its value
+		required_with=Field [Field]...                the field is required when any Field is not empty
+		required_without=Field [Field]...             the field is required when any Field is empty
+		excluded_if=Field value [Field value]...      the field must be empty when every Field has its value
+
+	Fields are named like the other field in a cross field rule, see fieldReference for more information. A value matches when it is equal to the formatted value of the field.
+	A field is empty when it has the zero value for its type, for instance "", 0 or a nil pointer, or when it is a slice or map with no elements, the same as for the required option, see isEmptyValue.
+
+	A field with conditional rules that is empty is not validated any further unless it also has the required parameter, so the field is optional whenever its conditions do not require it.
+*/
+type conditionalRule struct {
+	// name is the name of the rule in the tag data.
+	name string
+	// param is the parameter of the rule as it appears in the tag data.
+	param string
+	// fields contains the references to the fields in the conditions of the rule.
+	fields []fieldReference
+	// values contains the value each field is compared with, for the rules that compare values.
+	values []string
+}
+
+// extractConditionalRules removes the conditional rules from the tag items, returning the remaining items for the validator and the rules that were found.
+func extractConditionalRules(items []string) ([]string, []conditionalRule, error) {
+	rules := []conditionalRule{}
+	validatorItems := make([]string, 0, len(items))
+	for _, item := range items {
+		option, err := validator.ParseOption(item)
+		if err != nil {
+			return validatorItems, rules, err
+		}
+		// the values in the parameters may be quoted, so a value can contain spaces: required_if=City 'New York'.
+		params, err := option.Fields()
+		if err != nil {
+			return validatorItems, rules, err
+		}
+		rule := conditionalRule{
+			name:  option.Key,
+			param: option.Value,
+		}
+		switch rule.name {
+		case requiredIfRule, requiredUnlessRule, excludedIfRule:
+			if len(params) == 0 || len(params)%2 != 0 {
+				return validatorItems, rules, fmt.Errorf(conditionalPairsErrorTemplate, rule.name)
+			}
+			for i := 0; i < len(params); i += 2 {
+				rule.fields = append(rule.fields, parseFieldReference(params[i]))
+				rule.values = append(rule.values, params[i+1])
+			}
+		case requiredWithRule, requiredWithoutRule:
+			if len(params) == 0 {
+				return validatorItems, rules, fmt.Errorf(conditionalFieldsErrorTemplate, rule.name)
+			}
+			for _, param := range params {
+				rule.fields = append(rule.fields, parseFieldReference(param))
+			}
+		default:
+			validatorItems = append(validatorItems, item)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return validatorItems, rules, nil
+}
+
+// checkStructType returns an error when the rule references a field that does not exist in the struct type containing the field.
+func (cr conditionalRule) checkStructType(structType reflect.Type) error {
+	for _, reference := range cr.fields {
+		if err := reference.checkStructType(structType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applies returns true when the conditions of the rule are met, so the field is required, or for excluded_if must be empty.
+func (cr conditionalRule) applies(parent, root reflect.Value) (bool, error) {
+	for i, reference := range cr.fields {
+		other, ok, err := reference.resolve(parent, root)
+		if err != nil {
+			return false, err
+		}
+		switch cr.name {
+		case requiredWithRule:
+			if ok && !isEmptyValue(other) {
+				return true, nil
+			}
+		case requiredWithoutRule:
+			if !ok || isEmptyValue(other) {
+				return true, nil
+			}
+		default:
+			matches := ok && formatReflectValue(other) == cr.values[i]
+			if !matches {
+				// every field has to have its value, so the first field that does not is enough to decide.
+				return cr.name == requiredUnlessRule, nil
+			}
+		}
+	}
+	// every field was checked, so required_with and required_without found no field that requires the value, and every field had its value for the other rules.
+	return cr.name == requiredIfRule || cr.name == excludedIfRule, nil
+}
+
+/*
+	checkConditionalRules evaluates the conditional rules of a field, returning the errors for the rules that failed.
+	It returns true when the field is empty, in which case the field is not validated any further unless it has the required parameter.
+*/
+func checkConditionalRules(rules []conditionalRule, fieldValue, parent, root reflect.Value, fieldName string) ([]error, bool) {
+	errs := []error{}
+	isEmpty := isEmptyValue(fieldValue)
+	for _, rule := range rules {
+		applies, err := rule.applies(parent, root)
+		if err != nil {
+			errs = append(errs, validator.NewFieldError("", validator.CodeBadTag, fieldName, rule.name+"="+rule.param, nil, "").WithDetail("error", err.Error()))
+			continue
+		}
+		if !applies {
+			continue
+		}
+		if rule.name == excludedIfRule && !isEmpty {
+			errs = append(errs, validator.NewFieldError("", validator.CodeExcluded, fieldName, rule.param, fieldValue.Interface(), "").WithKey(rule.name))
+		} else if rule.name != excludedIfRule && isEmpty {
+			errs = append(errs, validator.NewFieldError("", validator.CodeRequired, fieldName, rule.param, nil, "").WithKey(rule.name))
+		}
+	}
+	return errs, isEmpty
+}
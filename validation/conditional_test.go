@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+type Order struct {
+	DeliveryMethod  string  `validate:"string"`
+	ShippingAddress Address `validate:"struct,required_if=DeliveryMethod ship"`
+	PickupStore     string  `validate:"string,min=3,required_unless=DeliveryMethod ship"`
+	Email           string  `validate:"string,required_without=Phone"`
+	Phone           string  `validate:"string,required_without=Email"`
+	PhoneExtension  string  `validate:"string,required_with=Phone,max=5"`
+	Coupon          *string `validate:"string,excluded_if=DeliveryMethod pickup"`
+}
+
+func TestConditionalRulesValid(t *testing.T) {
+	shipped := Order{
+		DeliveryMethod:  "ship",
+		ShippingAddress: Address{City: "Orlando", PostalCode: "32801"},
+		Email:           "test@user.com",
+	}
+	if validationError := ValidateStructWithTag(shipped); validationError != nil {
+		t.Error("the shipped order should have passed validation", validationError.Error())
+	}
+	// the empty shipping address is not validated because it is not required.
+	pickedUp := Order{
+		DeliveryMethod: "pickup",
+		PickupStore:    "Downtown",
+		Phone:          "5551234567",
+		PhoneExtension: "12",
+	}
+	if validationError := ValidateStructWithTag(pickedUp); validationError != nil {
+		t.Error("the picked up order should have passed validation", validationError.Error())
+	}
+}
+
+func TestConditionalRules(t *testing.T) {
+	coupon := "SAVE10"
+	testValue := Order{
+		DeliveryMethod: "pickup",
+		Phone:          "5551234567",
+		Coupon:         &coupon,
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	expected := map[string]error{
+		"PickupStore":    validator.ErrRequired,
+		"PhoneExtension": validator.ErrRequired,
+		"Coupon":         validator.ErrExcluded,
+	}
+	if len(validationError.Errors) != len(expected) {
+		t.Errorf("expected %d fields to fail but got: %s", len(expected), validationError.Error())
+	}
+	for field, sentinel := range expected {
+		if errs := validationError.Errors[field]; len(errs) != 1 || !errors.Is(errs[0], sentinel) {
+			t.Errorf("%s should have failed with %v but got %v", field, sentinel, errs)
+		}
+	}
+	testValue = Order{DeliveryMethod: "ship", PickupStore: "Downtown"}
+	validationError = ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	for _, field := range []string{"ShippingAddress", "Email", "Phone"} {
+		if errs := validationError.Errors[field]; len(errs) != 1 || !errors.Is(errs[0], validator.ErrRequired) {
+			t.Errorf("%s should have failed with a required error but got %v", field, errs)
+		}
+	}
+}
+
+func TestConditionalRuleTagErrors(t *testing.T) {
+	testValue := struct {
+		Missing string `validate:"string,required_with=Nope"`
+		Odd     string `validate:"string,required_if=Missing"`
+	}{}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	if errs := validationError.Errors["value"]; len(errs) != 2 || !errors.Is(errs[0], validator.ErrBadTag) || !errors.Is(errs[1], validator.ErrBadTag) {
+		t.Error("both fields should have bad tag errors", validationError.Error())
+	}
+	if len(validationError.Errors) != 1 {
+		t.Error("the rule with the bad tag should not be evaluated", validationError.Error())
+	}
+}
+
+func TestConditionalRulesEmptyCollections(t *testing.T) {
+	testValue := struct {
+		Kind   string            `validate:"string"`
+		Tags   []string          `validate:"[]string,required_if=Kind x"`
+		Labels map[string]string `validate:"map,required_with=Tags"`
+		Notes  []string          `validate:"[]string,required_without=Labels"`
+	}{Kind: "x", Tags: []string{}, Labels: map[string]string{}, Notes: []string{}}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("Tags and Notes should be required, since an empty slice or map is empty")
+	}
+	for _, field := range []string{"Tags", "Notes"} {
+		if errs := validationError.Errors[field]; len(errs) != 1 || !errors.Is(errs[0], validator.ErrRequired) {
+			t.Errorf("%s should have failed with a required error but got %v", field, errs)
+		}
+	}
+	if _, ok := validationError.Errors["Labels"]; ok {
+		t.Errorf("Labels should not be required, since Tags is empty: %v", validationError)
+	}
+}
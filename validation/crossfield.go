@@ -0,0 +1,190 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+const (
+	crossFieldCompareErrorTemplate     = "%s cannot compare a value of type %s with a value of type %s"
+	crossFieldNoReferenceErrorTemplate = "%s requires the name of a field to compare with"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	// crossFieldCodes contains the rule codes of the cross field rules, which are also the names of the rules in the tag data.
+	crossFieldCodes = map[string]bool{
+		validator.CodeEqField:  true,
+		validator.CodeNeField:  true,
+		validator.CodeGtField:  true,
+		validator.CodeGteField: true,
+		validator.CodeLtField:  true,
+		validator.CodeLteField: true,
+	}
+)
+
+/*
+	crossFieldRule compares the value of a field with the value of another field.
+
+	The other field is named by its Go field name, relative to the struct containing the field, or with a path from the top level struct when it starts with "$.":
+
+		`validate:"time,gtfield=StartTime"`
+		`validate:"string,eqfield=Password"`
+		`validate:"int,ltefield=$.Limits.MaxAge"`
+
+	Numbers, strings and time.Time values can be compared with every rule, other values can only be compared with eqfield and nefield.
+*/
+type crossFieldRule struct {
+	// code is the rule code, which is also the name of the rule in the tag data.
+	code string
+	// other is the reference to the field the value is compared with.
+	other fieldReference
+}
+
+// extractCrossFieldRules removes the cross field rules from the tag items, returning the remaining items for the validator and the rules that were found.
+func extractCrossFieldRules(items []string) ([]string, []crossFieldRule, error) {
+	rules := []crossFieldRule{}
+	validatorItems := make([]string, 0, len(items))
+	for _, item := range items {
+		option, err := validator.ParseOption(item)
+		if err != nil {
+			return validatorItems, rules, err
+		}
+		if !crossFieldCodes[option.Key] {
+			validatorItems = append(validatorItems, item)
+			continue
+		}
+		if option.Value == "" {
+			return validatorItems, rules, fmt.Errorf(crossFieldNoReferenceErrorTemplate, option.Key)
+		}
+		rules = append(rules, crossFieldRule{
+			code:  option.Key,
+			other: parseFieldReference(option.Value),
+		})
+	}
+	return validatorItems, rules, nil
+}
+
+// check compares the value of the field with the value of the field the rule references, returning an error when the comparison fails.
+// When either value is a nil pointer there is nothing to compare, so no error is returned.
+func (cfr crossFieldRule) check(fieldValue, parent, root reflect.Value, fieldName string) error {
+	other, ok, err := cfr.other.resolve(parent, root)
+	if err != nil {
+		return validator.NewFieldError("", validator.CodeBadTag, fieldName, cfr.code+"="+cfr.other.name, nil, "").WithDetail("error", err.Error())
+	}
+	value, valueOk := derefValue(fieldValue)
+	if !ok || !valueOk {
+		return nil
+	}
+	var passed bool
+	comparison, comparable := compareValues(value, other)
+	switch cfr.code {
+	case validator.CodeEqField, validator.CodeNeField:
+		equal := comparable && comparison == 0
+		if !comparable {
+			equal = reflect.DeepEqual(value.Interface(), other.Interface())
+		}
+		passed = equal == (cfr.code == validator.CodeEqField)
+	default:
+		if !comparable {
+			return validator.NewFieldError("", validator.CodeBadTag, fieldName, cfr.code+"="+cfr.other.name, nil, "").WithDetail("error", fmt.Sprintf(crossFieldCompareErrorTemplate, cfr.code, value.Type().String(), other.Type().String()))
+		}
+		switch cfr.code {
+		case validator.CodeGtField:
+			passed = comparison > 0
+		case validator.CodeGteField:
+			passed = comparison >= 0
+		case validator.CodeLtField:
+			passed = comparison < 0
+		case validator.CodeLteField:
+			passed = comparison <= 0
+		}
+	}
+	if passed {
+		return nil
+	}
+	return validator.NewFieldError("", cfr.code, fieldName, cfr.other.name, value.Interface(), "").WithDetail("other", formatReflectValue(other))
+}
+
+// compareValues compares two numbers, strings or time.Time values, returning -1, 0 or 1 like strings.Compare. It returns false when the values cannot be ordered.
+func compareValues(a, b reflect.Value) (int, bool) {
+	if a.Type() == timeType && b.Type() == timeType {
+		aTime, bTime := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch {
+		case aTime.Before(bTime):
+			return -1, true
+		case aTime.After(bTime):
+			return 1, true
+		}
+		return 0, true
+	}
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+	switch {
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		if a.Int() == b.Int() {
+			return 0, true
+		} else if a.Int() < b.Int() {
+			return -1, true
+		}
+		return 1, true
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		if a.Uint() == b.Uint() {
+			return 0, true
+		} else if a.Uint() < b.Uint() {
+			return -1, true
+		}
+		return 1, true
+	}
+	aFloat, aOk := toFloat(a)
+	bFloat, bOk := toFloat(b)
+	if aOk && bOk {
+		return compareFloats(aFloat, bFloat), true
+	}
+	return 0, false
+}
+
+// compareFloats returns -1, 0 or 1 when a is less than, equal to or greater than b.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+// toFloat converts any number to a float64, returning false when the value is not a number.
+func toFloat(value reflect.Value) (float64, bool) {
+	switch {
+	case isIntKind(value.Kind()):
+		return float64(value.Int()), true
+	case isUintKind(value.Kind()):
+		return float64(value.Uint()), true
+	case value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+		return value.Float(), true
+	}
+	return 0, false
+}
+
+// formatReflectValue formats a value for use as a message template parameter.
+func formatReflectValue(value reflect.Value) string {
+	if value.Type() == timeType {
+		return value.Interface().(time.Time).Format(time.RFC3339)
+	}
+	return fmt.Sprint(value.Interface())
+}
@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+type BookingLimits struct {
+	MaxGuests int `validate:"int,min=1"`
+}
+
+type Stay struct {
+	Guests int `validate:"int,ltefield=$.Limits.MaxGuests"`
+}
+
+type Booking struct {
+	StartTime       time.Time     `validate:"time"`
+	EndTime         time.Time     `validate:"time,gtfield=StartTime"`
+	Password        string        `validate:"string,min=3"`
+	PasswordConfirm string        `validate:"string,eqfield=Password"`
+	Username        string        `validate:"string,nefield=Password"`
+	MinPrice        float64       `validate:"float"`
+	MaxPrice        int           `validate:"int,gtefield=MinPrice"`
+	Limits          BookingLimits `validate:"struct"`
+	Stay            *Stay         `validate:"struct"`
+}
+
+func TestCrossFieldRulesValid(t *testing.T) {
+	start := time.Now()
+	testValue := Booking{
+		StartTime:       start,
+		EndTime:         start.Add(time.Hour),
+		Password:        "secret",
+		PasswordConfirm: "secret",
+		Username:        "calvin",
+		MinPrice:        10.5,
+		MaxPrice:        11,
+		Limits:          BookingLimits{MaxGuests: 4},
+		Stay:            &Stay{Guests: 2},
+	}
+	if validationError := ValidateStructWithTag(testValue); validationError != nil {
+		t.Error("the booking should have passed validation", validationError.Error())
+	}
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	start := time.Now()
+	testValue := Booking{
+		StartTime:       start,
+		EndTime:         start,
+		Password:        "secret",
+		PasswordConfirm: "secrets",
+		Username:        "secret",
+		MinPrice:        10.5,
+		MaxPrice:        10,
+		Limits:          BookingLimits{MaxGuests: 4},
+		Stay:            &Stay{Guests: 5},
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("the booking should have failed validation")
+	}
+	expected := map[string]string{
+		"EndTime":         validator.CodeGtField,
+		"PasswordConfirm": validator.CodeEqField,
+		"Username":        validator.CodeNeField,
+		"MaxPrice":        validator.CodeGteField,
+		"Stay.Guests":     validator.CodeLteField,
+	}
+	if len(validationError.Errors) != len(expected) {
+		t.Errorf("expected %d fields to fail but got: %s", len(expected), validationError.Error())
+	}
+	for _, fieldError := range validationError.FieldErrors() {
+		if expected[fieldError.Field] != fieldError.Code {
+			t.Errorf("%s should have failed with %s but failed with %s", fieldError.Field, expected[fieldError.Field], fieldError.Code)
+		}
+		if !errors.Is(fieldError, validator.ErrFieldComparison) {
+			t.Errorf("%s should wrap ErrFieldComparison", fieldError.Field)
+		}
+	}
+}
+
+func TestCrossFieldRuleTagErrors(t *testing.T) {
+	testValue := struct {
+		Confirm string        `validate:"string,eqfield=Missing"`
+		Other   []int         `validate:"[]int"`
+		Limit   int           `validate:"int,ltfield=Other"`
+		Total   int           `validate:"int,gtfield=$.Nope"`
+		Empty   string        `validate:"string,eqfield="`
+		Limits  BookingLimits `validate:"struct"`
+	}{Other: []int{1}}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("the bad cross field tags should have failed validation")
+	}
+	badTagFields := []string{}
+	for _, fieldError := range validationError.FieldErrors() {
+		if errors.Is(fieldError, validator.ErrBadTag) {
+			badTagFields = append(badTagFields, fieldError.Field)
+		}
+	}
+	expected := []string{"Confirm", "Empty", "Limit", "Total"}
+	if !reflect.DeepEqual(badTagFields, expected) {
+		t.Errorf("the bad tag errors should be for %v but were for %v", expected, badTagFields)
+	}
+}
+
+type EmbeddedLimits struct {
+	X int `validate:"int"`
+}
+
+type EmbeddedLimitsHolder struct {
+	*EmbeddedLimits
+	Y int `validate:"int,eqfield=X"`
+	Z int `validate:"int,required_without=X"`
+}
+
+func TestRulesThroughNilEmbeddedPointer(t *testing.T) {
+	validationError := ValidateStructWithTag(EmbeddedLimitsHolder{Y: 1})
+	if validationError == nil {
+		t.Fatal("Z should be required, since X cannot be resolved through the nil embedded pointer")
+	}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, []string{"Z"}) {
+		t.Errorf("only Z should have failed validation, the eqfield rule of Y has nothing to compare: %v", validationError)
+	}
+	validationError = ValidateStructWithTag(EmbeddedLimitsHolder{EmbeddedLimits: &EmbeddedLimits{X: 2}, Y: 1})
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, []string{"Y"}) {
+		t.Errorf("only Y should have failed validation once X can be resolved: %v", validationError)
+	}
+}
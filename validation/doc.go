@@ -1,5 +1,39 @@
 /*
 	The validation package handles validating data with validators from the validator package.
+
+	Validation is performed by an Engine, which owns its registered validators, the struct tag key it reads and its cache of compiled validation plans.
+	Use New to create an Engine with its own configuration:
+
+		engine := validation.New(validation.WithTagKey("check"), validation.WithValidator("phone", newPhoneValidator))
+		validationError := engine.ValidateStructWithTag(value)
+
+	Engine.ValidateCtx validates with a context. Validators implementing validator.ContextValidator receive the context, and once the context is done validation stops and the context error is returned:
+
+		validationError, err := engine.ValidateCtx(ctx, value)
+
+	ValidatePartial validates only the fields named by dotted paths like "Detail.Name", which is useful for PATCH requests, and ValidateExcept validates every field except the named ones.
+	ValidateGroups validates the fields and rules in the named validation groups.
+
+	WithFailFast and WithMaxErrors stop validation once enough errors are found, marking the ValidationError as Truncated, and WithFieldFailFast stops evaluating the rules of a field after its first failure.
+
+	WithCatalog sets the message catalog used to render errors for a locale, like ValidationError.RenderLocale, for a single Engine instead of registering it for the whole program with validator.RegisterCatalog:
+
+		engine := validation.New(validation.WithCatalog("es", catalog))
+		message := engine.ValidateStructWithTag(value).RenderLocale(validation.FormatMultiLine, "es")
+
+	Struct types with invariants that do not fit into tag data can implement SelfValidator, or SelfReporter to report errors for individual fields.
+	They are called after the fields of the struct are validated, and their errors are part of the same ValidationError.
+
+	Check reports problems with the tag data of a struct type without validating a value, like unregistered validator names, unknown options and validators that cannot validate the field type.
+	WithStrictOptions makes those unknown options an error when a value is validated too, along with options missing their value and values given to flag options like required=false.
+	MustCompile panics on those problems, so they can be found at startup:
+
+		func init() {
+			validation.MustCompile(Booking{})
+		}
+
+	The package level functions like ValidateStructWithTag and RegisterValidator use a default Engine shared by the whole program.
+
 	For more info on validators or the tag syntax for validating struct fields please see the documentation for the validator package.
 */
 package validation
@@ -0,0 +1,587 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/calvine/simplevalidation/validation/validationparams"
+	"github.com/calvine/simplevalidation/validator"
+	"github.com/calvine/simplevalidation/validator/emailvalidator"
+	"github.com/calvine/simplevalidation/validator/floatvalidator"
+	"github.com/calvine/simplevalidation/validator/intvalidator"
+	"github.com/calvine/simplevalidation/validator/postalcodevalidator"
+	"github.com/calvine/simplevalidation/validator/stringvalidator"
+	"github.com/calvine/simplevalidation/validator/timevalidator"
+	"github.com/calvine/simplevalidation/validator/uintvalidator"
+	"github.com/calvine/simplevalidation/validator/uuidvalidator"
+)
+
+const (
+	// DefaultTagKey is the struct field tag key read by an Engine unless WithTagKey is used.
+	DefaultTagKey = "validate"
+
+	emptyValidatorNameErrorTemplate = "the tag data has no validator name"
+)
+
+/*
+	Engine performs validation with its own set of registered validators, tag key and compiled plan cache.
+
+	Each Engine is independent of every other Engine, so validators and catalogs registered with one Engine are not visible to any other.
+	The package level functions like ValidateStructWithTag and RegisterValidator use a default Engine created with New().
+*/
+type Engine struct {
+	// validators contains the validators and aliases registered with the Engine.
+	validators *registry
+	// tagKey is the struct field tag key that contains the validation tag data.
+	tagKey string
+	// nameFunc returns the name used for a struct field in validation errors.
+	nameFunc NameFunc
+	// errorFormat is the ErrorFormat given to each ValidationError produced by the Engine.
+	errorFormat ErrorFormat
+	// maxErrors is the number of errors after which validation stops. It is 0 when validation never stops early.
+	maxErrors int
+	// fieldFailFast is true when the rules of a field stop being evaluated after the first one fails.
+	fieldFailFast bool
+	// strictOptions is true when tag data with options the validator does not accept is a configuration error.
+	strictOptions bool
+	// catalogs contains the message catalogs set with WithCatalog for each locale. It is not modified once the Engine is created, so it is shared with the ValidationErrors the Engine produces.
+	catalogs map[string]validator.Catalog
+	// planCache holds a *sync.Map containing a *structPlan for each reflect.Type that has been validated by this Engine.
+	// The whole map is replaced when the registered validators change, so a plan compiled with the old validators is never stored in the new map.
+	planCache atomic.Value
+}
+
+// Option is a function that configures an Engine when passed to New.
+type Option func(*Engine)
+
+var (
+	// defaultEngine is the Engine used by the package level functions.
+	defaultEngine = New()
+)
+
+// builtInValidators returns a new map containing a validator.ValidatorFactory for each validator in the validator package.
+func builtInValidators() map[string]validator.ValidatorFactory {
+	return map[string]validator.ValidatorFactory{
+		"email":      emailvalidator.New,
+		"float":      floatvalidator.New,
+		"int":        intvalidator.New,
+		"uint":       uintvalidator.New,
+		"postalcode": postalcodevalidator.New,
+		"string":     stringvalidator.New,
+		"time":       timevalidator.New,
+		"uuid":       uuidvalidator.New,
+	}
+}
+
+// New creates an Engine with the built in validators registered, then applies each option provided.
+func New(opts ...Option) *Engine {
+	e := &Engine{
+		validators: newRegistry(builtInValidators()),
+		tagKey:     DefaultTagKey,
+		nameFunc:   GoFieldName,
+	}
+	e.planCache.Store(&sync.Map{})
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Default returns the Engine used by the package level functions.
+func Default() *Engine {
+	return defaultEngine
+}
+
+// WithTagKey sets the struct field tag key the Engine reads validation tag data from. The default is "validate".
+func WithTagKey(tagKey string) Option {
+	return func(e *Engine) {
+		e.tagKey = tagKey
+	}
+}
+
+// WithErrorFormat sets the ErrorFormat used by ValidationError.Error for errors produced by the Engine. The default is FormatMultiLine.
+func WithErrorFormat(format ErrorFormat) Option {
+	return func(e *Engine) {
+		e.errorFormat = format
+	}
+}
+
+/*
+	WithCatalog sets the validator.Catalog the ValidationErrors produced by the Engine are rendered with for the locale, for instance by ValidationError.RenderLocale.
+
+	A locale without a catalog set on the Engine uses the catalog registered with validator.RegisterCatalog, and templates missing from either fall back to the English catalog.
+*/
+func WithCatalog(locale string, catalog validator.Catalog) Option {
+	return func(e *Engine) {
+		catalogs := make(map[string]validator.Catalog, len(e.catalogs)+1)
+		for existingLocale, existingCatalog := range e.catalogs {
+			catalogs[existingLocale] = existingCatalog
+		}
+		catalogs[locale] = catalog
+		e.catalogs = catalogs
+	}
+}
+
+// WithFailFast makes the Engine stop validating at the first failure, which is useful when only a yes or no answer is needed. It is the same as WithMaxErrors(1).
+func WithFailFast() Option {
+	return WithMaxErrors(1)
+}
+
+// WithMaxErrors makes the Engine stop validating once maxErrors errors are found, marking the ValidationError as Truncated. A maxErrors of 0 means there is no limit, which is the default.
+func WithMaxErrors(maxErrors int) Option {
+	return func(e *Engine) {
+		e.maxErrors = maxErrors
+	}
+}
+
+// WithFieldFailFast makes the Engine stop evaluating the rules of a field after the first one fails, so each field has at most one error.
+// The elements of a slice, array or map are still validated separately.
+func WithFieldFailFast() Option {
+	return func(e *Engine) {
+		e.fieldFailFast = true
+	}
+}
+
+/*
+	WithStrictOptions makes the Engine reject tag data with options the validator does not accept, instead of ignoring them.
+
+	Any option key the validator does not declare, an option missing its value like min, and a value given to a flag option like required=false is reported as a bad tag error, by Check and when a value is validated.
+	Only validators that implement validator.OptionDescriber declare their options, so the options of other validators are not checked.
+*/
+func WithStrictOptions() Option {
+	return func(e *Engine) {
+		e.strictOptions = true
+	}
+}
+
+// WithValidator registers a custom validator with the Engine. It always replaces a validator already registered with the same name, regardless of the DuplicatePolicy.
+func WithValidator(name string, customValidatorFactory validator.ValidatorFactory) Option {
+	return func(e *Engine) {
+		e.validators.factories[name] = customValidatorFactory
+	}
+}
+
+// WithoutBuiltInValidators removes the built in validators from the Engine, so only validators registered with WithValidator or RegisterValidator are available.
+func WithoutBuiltInValidators() Option {
+	return func(e *Engine) {
+		e.validators.factories = map[string]validator.ValidatorFactory{}
+	}
+}
+
+// WithDuplicatePolicy sets what RegisterValidator and RegisterAlias do when the name is already registered. The default is DuplicateOverride.
+func WithDuplicatePolicy(policy DuplicatePolicy) Option {
+	return func(e *Engine) {
+		e.validators.policy = policy
+	}
+}
+
+// RegisterValidator registers a custom validator with the Engine, so it can be read from struct field tag validation data.
+// When the name is already registered the Engine's DuplicatePolicy determines whether it is replaced or an error wrapping ErrDuplicateValidator is returned.
+// Registering a validator clears the Engine's compiled plans, so they are rebuilt with the new validator.
+func (e *Engine) RegisterValidator(name string, customValidatorFactory validator.ValidatorFactory) error {
+	if err := e.validators.register(name, customValidatorFactory); err != nil {
+		return err
+	}
+	e.resetPlanCache()
+	return nil
+}
+
+// RegisterAlias makes alias usable in tag data in place of the validator registered under name.
+// When the alias is already registered the Engine's DuplicatePolicy determines whether it is replaced or an error wrapping ErrDuplicateValidator is returned.
+func (e *Engine) RegisterAlias(alias, name string) error {
+	if err := e.validators.registerAlias(alias, name); err != nil {
+		return err
+	}
+	e.resetPlanCache()
+	return nil
+}
+
+// UnregisterValidator removes the validator or alias registered under name. Removing a validator also removes its aliases.
+// It returns false if nothing was registered under name.
+func (e *Engine) UnregisterValidator(name string) bool {
+	removed := e.validators.unregister(name)
+	if removed {
+		e.resetPlanCache()
+	}
+	return removed
+}
+
+// RegisteredValidators returns the sorted names of the validators registered with the Engine, not including aliases.
+func (e *Engine) RegisteredValidators() []string {
+	return e.validators.names()
+}
+
+// RegisteredAliases returns a map of each alias registered with the Engine to the validator name it refers to.
+func (e *Engine) RegisteredAliases() map[string]string {
+	return e.validators.aliasMap()
+}
+
+// getValidatorFromTag Takes in the validator name from the tag data and returns an instance of the appropriate validator.
+// When the validatorName parameter is not registererd with the Engine, the function returns an error.
+func (e *Engine) getValidatorFromTag(validatorName, fieldName string) (validator.Validator, error) {
+	if validatorName == "" {
+		return nil, validator.NewFieldError("", validator.CodeBadTag, fieldName, "", nil, "").WithDetail("error", emptyValidatorNameErrorTemplate)
+	}
+	if validatorName == structValidatorName {
+		return nil, nil
+	}
+	typeValidatorFactory, ok := e.validators.lookup(validatorName)
+	if !ok {
+		return nil, validator.NewFieldError(validatorName, validator.CodeNoValidator, fieldName, "", nil, "")
+	}
+	return typeValidatorFactory(), nil
+}
+
+/*
+	performFieldValidation is the core of the validation work flow. it takes a ValidationParams struct and a pointer to a validationErrorMap.
+ 	It then proceeds to call its self recursivly, until all validation is completed. Upon completion the validationErrors parameter is populated with all errors arising from validation.
+
+	This function handles the following cases:
+		- When the value is empty the presence options decide what happens, see presenceOptions for more information.
+			- When the validationparams.ValidationParams.Nullable field is true a nil pointer is valid.
+			- When the validationparams.ValidationParams.Required field is true it will register a validation error and skip the rest of the validation.
+			- When the validationparams.ValidationParams.OmitEmpty field is true the rest of the validation is skipped.
+		- When the value being validated is a pointer it is dereferenced, and the validated.
+			- When that pointer is nil validation is skipped.
+		- When the field has map tag data the keys and values of the map are validated with the tag data for each, see isMapTag for more information.
+		- When the validationparams.ValidationParams.ArrayDepth is greater than 0 the function will iterate of the array / slice and validate each value for each level of array / slice. The elements are traversed as structs when the validator name is "struct", for instance "[]struct".
+		- When the field being validated is a struct the struct fields are traversed using the cached structPlan for the struct type, which holds the validators built from the validator tag data.
+			- Before a field is validated its conditional rules decide if it is required, see conditionalRule for more information.
+			- After a field is validated its cross field rules compare it with the other fields of the struct, see crossFieldRule for more information.
+			- After the fields are validated a struct implementing SelfValidator or SelfReporter validates itself.
+		- When the field is any other kind it will attempt to validate the value.
+
+	Once the context of the validation run is done, or the maximum number of errors is reached, no further values are validated.
+*/
+func (e *Engine) performFieldValidation(validationInfo validationparams.ValidationParams, field *fieldPlan, state *validationState) {
+	if state.stopped() {
+		// the context is done, so the rest of the value is not traversed.
+		return
+	}
+	fieldErrors := []error{}
+	value := reflect.ValueOf(validationInfo.Value)
+	if isEmptyValue(value) {
+		switch {
+		case validationInfo.Nullable && isNullValue(value):
+			// a nullable value may be nil, even when it is required.
+			return
+		case validationInfo.Required:
+			// a required value that is empty has nothing more to validate.
+			fieldErrors = append(fieldErrors, validator.NewFieldError("", validator.CodeRequired, validationInfo.Name, "", nil, ""))
+			if field != nil {
+				fieldErrors = field.messages.apply(fieldErrors)
+			}
+			state.addErrors(validationInfo.Name, fieldErrors...)
+			return
+		case validationInfo.OmitEmpty:
+			// an empty value is not validated with omitempty.
+			return
+		}
+	}
+	if !value.IsValid() {
+		// a nil interface has no value to validate.
+		return
+	}
+	kind := value.Kind()
+	vType := value.Type()
+	// fmt.Printf("%v - %v\n\n", kind, vType.String())
+	if kind == reflect.Ptr {
+		// handle pointers, a nil pointer that is not required is not validated.
+		if !value.IsNil() {
+			fieldValue := value.Elem().Interface()
+			recursiveFieldValidator := validationparams.ValidationParams{
+				ArrayDepth:     validationInfo.ArrayDepth,
+				Name:           validationInfo.Name,
+				FieldValidator: validationInfo.FieldValidator,
+				// the presence options apply to the pointer, which is provided once it is not nil, so the value it points to is validated as is.
+				Required:    false,
+				OmitEmpty:   false,
+				Nullable:    validationInfo.Nullable,
+				StructDepth: validationInfo.StructDepth,
+				Value:       fieldValue,
+			}
+			e.performFieldValidation(recursiveFieldValidator, field, state)
+		}
+	} else if field != nil && field.isMap && validationInfo.FieldValidator == nil {
+		// handle maps, validating each key and value.
+		fieldErrors = append(fieldErrors, e.validateMap(validationInfo, field, state)...)
+	} else if validationInfo.ArrayDepth > 0 {
+		// potentially nested array element validation, the elements may be structs when the validator name is "struct".
+		currentArrayDepth := validationInfo.ArrayDepth
+		switch kind {
+		case reflect.Slice, reflect.Array:
+			currentArrayDepth--
+			if field != nil && !field.collection.isEmpty() {
+				// collection rules are evaluated on the slice before its elements are validated, so their errors come first.
+				collectionErrors := state.limitFieldErrors(field.collection.check(value, validationInfo.Name))
+				state.addErrors(validationInfo.Name, field.messages.apply(collectionErrors)...)
+				if state.fieldFailFast && len(collectionErrors) > 0 {
+					break
+				}
+			}
+			for i := 0; i < value.Len(); i++ {
+				if state.stopped() {
+					break
+				}
+				e.performFieldValidation(validationparams.ValidationParams{
+					ArrayDepth:     currentArrayDepth,
+					FieldValidator: validationInfo.FieldValidator,
+					Name:           fmt.Sprintf("%s[%d]", validationInfo.Name, i),
+					// the presence options apply to the slice as a whole, so an element with its zero value is validated like any other element.
+					Required:    false,
+					OmitEmpty:   false,
+					Nullable:    validationInfo.Nullable,
+					StructDepth: validationInfo.StructDepth,
+					Value:       value.Index(i).Interface(),
+				}, field, state)
+			}
+		default:
+			// This should not happen. add error...
+		}
+	} else if kind == reflect.Struct && validationInfo.FieldValidator == nil {
+		// handle structs and embedded structs.
+		structDepth := validationInfo.StructDepth + 1
+		plan := e.getStructPlan(vType, state.groups)
+		// errors compiling the tag data are recorded against the struct before its fields are validated, so they come first in the struct's errors.
+		for _, fieldPlan := range plan.fields {
+			// make a custom type not registered / tag invalid error?
+			fieldErrors = append(fieldErrors, fieldPlan.compileErrors()...)
+		}
+		state.addErrors(validationInfo.Name, fieldErrors...)
+		fieldErrors = nil
+		filter := state.filter
+		for i := range plan.fields {
+			fieldPlan := &plan.fields[i]
+			fieldFilter, included := filter.child(fieldPlan)
+			if !included {
+				continue
+			}
+			state.filter = fieldFilter
+			e.validateStructField(value, fieldPlan, validationInfo.Name, structDepth, state)
+		}
+		state.filter = filter
+		if filter == nil && plan.self.implemented() && !state.stopped() {
+			// the struct validates itself after its fields, so its own errors come after the errors of its fields.
+			// when only some of its fields are validated the struct does not validate itself, as it may report errors for the other fields.
+			plan.self.validate(e, value, validationInfo.Name, structDepth, state)
+		}
+	} else if validationInfo.FieldValidator != nil {
+		// perform normal field validation.
+		_, fieldError := callValidator(state.ctx, validationInfo.FieldValidator, validationInfo.Value, validationInfo.Name, kind)
+		if fieldError != nil {
+			fieldErrors = append(fieldErrors, fieldError)
+		}
+	} // else { panic? }
+	if field != nil {
+		fieldErrors = field.messages.apply(fieldErrors)
+	}
+	state.addErrors(validationInfo.Name, fieldErrors...)
+}
+
+// validateStructField validates a field of a struct value with its fieldPlan, including the conditional and cross field rules of the field.
+func (e *Engine) validateStructField(structValue reflect.Value, fieldPlan *fieldPlan, structName string, structDepth uint8, state *validationState) {
+	if fieldPlan.err != nil && fieldPlan.fieldValidator == nil {
+		// the validator is not registered so there is nothing to validate the field with.
+		return
+	}
+	fieldName := fieldPlan.fieldPath(structName, structDepth)
+	fieldValue := structValue.Field(fieldPlan.index)
+	errorCount := state.errorCount
+	if len(fieldPlan.conditions) > 0 {
+		conditionErrors, isEmpty := checkConditionalRules(fieldPlan.conditions, fieldValue, structValue, state.root, fieldName)
+		state.addErrors(fieldName, fieldPlan.messages.apply(state.limitFieldErrors(conditionErrors))...)
+		if state.fieldFailed(errorCount) || (isEmpty && !fieldPlan.required) {
+			// an empty field with conditional rules is optional unless its conditions require it, and either way there is nothing more to validate.
+			return
+		}
+	}
+	if fieldPlan.omitEmpty && isEmptyValue(fieldValue) {
+		// with omitempty an empty field is not validated, which includes its cross field rules.
+		return
+	}
+	validationData := validationparams.ValidationParams{
+		ArrayDepth:     fieldPlan.arrayDepth,
+		FieldValidator: fieldPlan.fieldValidator,
+		Name:           fieldName,
+		Required:       fieldPlan.required,
+		OmitEmpty:      fieldPlan.omitEmpty,
+		Nullable:       fieldPlan.nullable,
+		StructDepth:    structDepth,
+		Value:          fieldValue.Interface(),
+	}
+	e.performFieldValidation(validationData, fieldPlan, state)
+	if len(fieldPlan.crossFields) > 0 && !state.fieldFailed(errorCount) {
+		crossFieldErrors := []error{}
+		for _, rule := range fieldPlan.crossFields {
+			if err := rule.check(fieldValue, structValue, state.root, fieldName); err != nil {
+				crossFieldErrors = append(crossFieldErrors, err)
+			}
+		}
+		state.addErrors(fieldName, fieldPlan.messages.apply(state.limitFieldErrors(crossFieldErrors))...)
+	}
+}
+
+// Validate validates a value with the validator provided in the ValidationParams.
+// The Validator parameter is present to allow for validating non struct values. In this function A Validator pointer can be passed in and evaluated on a non struct value like an individual int or string.
+func (e *Engine) Validate(v *validationparams.ValidationParams) (*ValidationError, error) {
+	if v == nil {
+		return nil, errors.New("no FieldValidationData provided")
+	}
+	state := e.newValidationState(context.Background(), v.Value, defaultGroups)
+	e.performFieldValidation(*v, nil, state)
+	return e.newValidationError(state, v.Value), nil
+}
+
+// ValidateStructWithTag validates an input struct based on the validation tags is has in its tag data.
+func (e *Engine) ValidateStructWithTag(s interface{}) *ValidationError {
+	validationError, _ := e.ValidateCtx(context.Background(), s)
+	return validationError
+}
+
+/*
+	ValidateCtx validates an input struct based on the validation tags it has in its tag data, like ValidateStructWithTag.
+
+	The context is passed to every validator that implements validator.ContextValidator.
+	Once the context is done the traversal stops and ValidateCtx returns ctx.Err() instead of a ValidationError.
+*/
+func (e *Engine) ValidateCtx(ctx context.Context, s interface{}) (*ValidationError, error) {
+	return e.validate(e.newValidationState(ctx, s, defaultGroups), s)
+}
+
+// validate validates an input struct based on its validation tag data, with the provided state for the validation run.
+func (e *Engine) validate(state *validationState, s interface{}) (*ValidationError, error) {
+	validationData := validationparams.New()
+	validationData.Value = s
+	// default name for value being validated.
+	validationData.Name = "value"
+	e.performFieldValidation(validationData, nil, state)
+	if err := state.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return e.newValidationError(state, s), nil
+}
+
+// callValidator validates the value with the validator, passing the context when the validator implements validator.ContextValidator.
+func callValidator(ctx context.Context, fieldValidator validator.Validator, value interface{}, fieldName string, kind reflect.Kind) (bool, error) {
+	if contextValidator, ok := fieldValidator.(validator.ContextValidator); ok {
+		return contextValidator.ValidateContext(ctx, value, fieldName, kind)
+	}
+	return fieldValidator.Validate(value, fieldName, kind)
+}
+
+// newValidationError builds the ValidationError for a completed validation run. It returns nil when the run produced no errors.
+func (e *Engine) newValidationError(state *validationState, value interface{}) *ValidationError {
+	if len(state.errors) == 0 {
+		return nil
+	}
+	return &ValidationError{
+		DataType:  dataTypeName(value),
+		Errors:    state.errors,
+		Format:    e.errorFormat,
+		Truncated: state.truncated,
+		fields:    state.order,
+		catalogs:  e.catalogs,
+	}
+}
+
+// dataTypeName returns the name of the type of the value, dereferencing pointers. It returns an empty string for unnamed types.
+func dataTypeName(value interface{}) string {
+	valueType := reflect.TypeOf(value)
+	for valueType != nil && valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	if valueType == nil {
+		return ""
+	}
+	return valueType.Name()
+}
+
+/*
+	validationState holds the state of a single validation run.
+*/
+type validationState struct {
+	// ctx is the context of the validation run.
+	ctx context.Context
+	// root is the top level value being validated, used to resolve cross field references that start with "$.".
+	root reflect.Value
+	// groups contains the validation groups of the validation run.
+	groups groupSet
+	// filter selects the fields of the current struct that are validated. It is nil when every field is validated.
+	filter *pathFilter
+	// maxErrors is the number of errors after which validation stops. It is 0 when validation never stops early.
+	maxErrors int
+	// errorCount is the number of errors added so far.
+	errorCount int
+	// truncated is true when errors were dropped or values were skipped because maxErrors was reached, so validation stopped early.
+	truncated bool
+	// fieldFailFast is true when the rules of a field stop being evaluated after the first one fails.
+	fieldFailFast bool
+	// errors contains the errors for each field that failed validation.
+	errors validationErrorMap
+	// order contains the keys of errors in the order they were first added, which is the declaration order of the fields.
+	order []string
+}
+
+// newValidationState creates the state for a new validation run with the Engine's settings.
+func (e *Engine) newValidationState(ctx context.Context, root interface{}, groups groupSet) *validationState {
+	return &validationState{
+		ctx:           ctx,
+		root:          reflect.ValueOf(root),
+		groups:        groups,
+		errors:        validationErrorMap{},
+		maxErrors:     e.maxErrors,
+		fieldFailFast: e.fieldFailFast,
+	}
+}
+
+// stopped returns true when the validation run should not validate any more values.
+// When it returns true because maxErrors was reached, the value is marked as truncated, since the values that are skipped may have failures that are not reported.
+func (s *validationState) stopped() bool {
+	if s.ctx.Err() != nil {
+		return true
+	}
+	if s.maxErrors > 0 && s.errorCount >= s.maxErrors {
+		s.truncated = true
+		return true
+	}
+	return false
+}
+
+// fieldFailed returns true when the rules of the current field should stop being evaluated, because fieldFailFast is true and errors were added since errorCount.
+func (s *validationState) fieldFailed(errorCount int) bool {
+	return s.fieldFailFast && s.errorCount > errorCount
+}
+
+// limitFieldErrors returns only the first of the errors for a field when fieldFailFast is true.
+func (s *validationState) limitFieldErrors(errs []error) []error {
+	if s.fieldFailFast && len(errs) > 1 {
+		return errs[:1]
+	}
+	return errs
+}
+
+// addErrors appends the errors to the errors for the named field.
+func (s *validationState) addErrors(name string, errs ...error) {
+	if len(errs) == 0 {
+		return
+	}
+	if s.maxErrors > 0 {
+		if remaining := s.maxErrors - s.errorCount; len(errs) > remaining {
+			// the errors past maxErrors are dropped, so the reported errors are not every failure of the value.
+			errs = errs[:remaining]
+			s.truncated = true
+		}
+		if len(errs) == 0 {
+			return
+		}
+	}
+	if _, ok := s.errors[name]; !ok {
+		s.order = append(s.order, name)
+	}
+	s.errors[name] = append(s.errors[name], errs...)
+	s.errorCount += len(errs)
+}
@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+// phoneValidator is a minimal custom validator used to test validator registration.
+type phoneValidator struct{}
+
+func newPhoneValidator() validator.Validator {
+	return &phoneValidator{}
+}
+
+func (pv *phoneValidator) Validate(n interface{}, fieldName string, fieldKind reflect.Kind) (bool, error) {
+	value, ok := n.(string)
+	if !ok || len(value) != 10 {
+		return false, errors.New(fmt.Sprintf("invalid: the field %s is not a valid phone number", fieldName))
+	}
+	return true, nil
+}
+
+func (pv *phoneValidator) ReadOptionsFromTagItems(items []string) error {
+	return nil
+}
+
+type PhoneItem struct {
+	Phone string `validate:"phone"`
+}
+
+func TestEngineRegistriesAreIndependent(t *testing.T) {
+	withPhone := New(WithValidator("phone", newPhoneValidator))
+	withoutPhone := New()
+	testValue := PhoneItem{Phone: "123"}
+	validationError := withPhone.ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Error("Phone should have failed validation with the phone validator")
+	} else if _, ok := validationError.Errors["Phone"]; !ok {
+		t.Error("validationError.Errors should contain key 'Phone'", validationError.Error())
+	}
+	validationError = withoutPhone.ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Error("phone should not be registered with an engine it was not registered with")
+	} else if _, ok := validationError.Errors["value"]; !ok {
+		t.Error("validationError.Errors should contain a no validator error under key 'value'", validationError.Error())
+	}
+	if validationError = ValidateStructWithTag(testValue); validationError == nil {
+		t.Error("phone should not be registered with the default engine")
+	}
+}
+
+func TestEngineRegisterValidatorClearsPlans(t *testing.T) {
+	e := New()
+	testValue := PhoneItem{Phone: "1234567890"}
+	if validationError := e.ValidateStructWithTag(testValue); validationError == nil {
+		t.Error("phone is not registered yet so validation should fail")
+	}
+	e.RegisterValidator("phone", newPhoneValidator)
+	if validationError := e.ValidateStructWithTag(testValue); validationError != nil {
+		t.Error("phone is registered so validation should succeed", validationError.Error())
+	}
+}
+
+func TestEngineWithTagKey(t *testing.T) {
+	testValue := struct {
+		Name string `check:"string,min=5"`
+	}{
+		Name: "abc",
+	}
+	if validationError := New().ValidateStructWithTag(testValue); validationError != nil {
+		t.Error("the default engine should ignore the check tag", validationError.Error())
+	}
+	validationError := New(WithTagKey("check")).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Error("Name should fail validation when the engine reads the check tag")
+	} else if _, ok := validationError.Errors["Name"]; !ok {
+		t.Error("validationError.Errors should contain key 'Name'", validationError.Error())
+	}
+}
+
+func TestEngineWithoutBuiltInValidators(t *testing.T) {
+	e := New(WithoutBuiltInValidators())
+	testValue := struct {
+		Name string `validate:"string"`
+	}{}
+	if validationError := e.ValidateStructWithTag(testValue); validationError == nil {
+		t.Error("string should not be registered when built in validators are removed")
+	}
+}
+
+// contextPhoneValidator records the context it is called with to test validator.ContextValidator support.
+type contextPhoneValidator struct {
+	phoneValidator
+	ctx *context.Context
+}
+
+func (cpv *contextPhoneValidator) ValidateContext(ctx context.Context, n interface{}, fieldName string, fieldKind reflect.Kind) (bool, error) {
+	*cpv.ctx = ctx
+	return cpv.Validate(n, fieldName, fieldKind)
+}
+
+type ctxKey struct{}
+
+func TestEngineValidateCtxPassesContext(t *testing.T) {
+	var received context.Context
+	e := New(WithValidator("phone", func() validator.Validator {
+		return &contextPhoneValidator{ctx: &received}
+	}))
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	validationError, err := e.ValidateCtx(ctx, PhoneItem{Phone: "1234567890"})
+	if err != nil || validationError != nil {
+		t.Errorf("validation should have passed: %v %v", err, validationError)
+	}
+	if received == nil || received.Value(ctxKey{}) != "value" {
+		t.Error("the context validator should have received the context passed to ValidateCtx")
+	}
+}
+
+func TestEngineValidateCtxCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	validationError, err := ValidateStructWithTagContext(ctx, PhoneItem{Phone: "123"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err should be context.Canceled but was %v", err)
+	}
+	if validationError != nil {
+		t.Error("validationError should be nil when the context is cancelled", validationError.Error())
+	}
+}
@@ -0,0 +1,180 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+/*
+	ErrorFormat determines how ValidationError.Error formats the validation failures.
+*/
+type ErrorFormat uint8
+
+const (
+	/*
+		FormatMultiLine puts each failure on its own indented line. This is the default format:
+
+			TestStruct validation failed:
+				Age: max: The field Age value 200 is greater than the maximum value 150
+				Detail.Name: max length: ...
+	*/
+	FormatMultiLine ErrorFormat = iota
+	/*
+		FormatSingleLine puts every failure on one line separated by semicolons, which is useful for log lines:
+
+			TestStruct validation failed: Age: max: ...; Detail.Name: max length: ...
+	*/
+	FormatSingleLine
+	/*
+		FormatTree nests each failure under the path to its field, with one level of indentation for each struct field or array index:
+
+			TestStruct validation failed:
+				Age:
+					max: ...
+				Detail:
+					Name:
+						max length: ...
+	*/
+	FormatTree
+)
+
+// Render formats the validation failures in the DefaultLocale with the provided ErrorFormat. The failures are in the order the fields were validated.
+func (e *ValidationError) Render(format ErrorFormat) string {
+	return e.RenderLocale(format, validator.DefaultLocale)
+}
+
+// RenderLocale formats the validation failures with the provided ErrorFormat, using the message catalog for the locale, see WithCatalog.
+func (e *ValidationError) RenderLocale(format ErrorFormat, locale string) string {
+	var errorBuffer bytes.Buffer
+	catalog := e.catalog(locale)
+	dataType := e.DataType
+	if dataType == "" {
+		dataType = "value"
+	}
+	header, _ := validator.LookupCatalogTemplate(catalog, validator.ValidationFailedKey)
+	errorBuffer.WriteString(validator.RenderTemplate(header, map[string]string{"type": dataType}))
+	switch format {
+	case FormatSingleLine:
+		separator := " "
+		for _, key := range e.orderedKeys() {
+			for _, err := range e.Errors[key] {
+				fmt.Fprintf(&errorBuffer, "%s%s: %s", separator, key, validator.LocalizeCatalogError(err, catalog))
+				separator = "; "
+			}
+		}
+	case FormatTree:
+		root := &errorTreeNode{}
+		for _, key := range e.orderedKeys() {
+			root.insert(splitFieldPath(key), e.Errors[key])
+		}
+		root.render(&errorBuffer, 1, catalog)
+	default:
+		for _, key := range e.orderedKeys() {
+			for _, err := range e.Errors[key] {
+				fmt.Fprintf(&errorBuffer, "\n\t%s: %s", key, validator.LocalizeCatalogError(err, catalog))
+			}
+		}
+	}
+	if e.Truncated {
+		truncated, _ := validator.LookupCatalogTemplate(catalog, validator.ValidationTruncatedKey)
+		separator := "\n\t"
+		if format == FormatSingleLine {
+			separator = " "
+		}
+		errorBuffer.WriteString(separator + truncated)
+	}
+	return errorBuffer.String()
+}
+
+// catalog returns the message catalog for the locale, which is the catalog set on the Engine with WithCatalog or else the catalog registered with validator.RegisterCatalog. It returns nil when neither has a catalog for the locale.
+func (e *ValidationError) catalog(locale string) validator.Catalog {
+	if catalog, ok := e.catalogs[locale]; ok {
+		return catalog
+	}
+	catalog, _ := validator.CatalogFor(locale)
+	return catalog
+}
+
+// Localize returns the messages for each field that failed validation, rendered with the message catalog for the locale, see WithCatalog.
+func (e *ValidationError) Localize(locale string) map[string][]string {
+	catalog := e.catalog(locale)
+	messages := make(map[string][]string, len(e.Errors))
+	for key, errs := range e.Errors {
+		for _, err := range errs {
+			messages[key] = append(messages[key], validator.LocalizeCatalogError(err, catalog))
+		}
+	}
+	return messages
+}
+
+// errorTreeNode is a single segment of a field path used to render FormatTree.
+type errorTreeNode struct {
+	name     string
+	errors   []error
+	children []*errorTreeNode
+}
+
+// insert adds the errors to the node at the provided path below this node, creating nodes as needed while keeping the order they were first inserted.
+func (n *errorTreeNode) insert(path []string, errs []error) {
+	if len(path) == 0 {
+		n.errors = append(n.errors, errs...)
+		return
+	}
+	for _, child := range n.children {
+		if child.name == path[0] {
+			child.insert(path[1:], errs)
+			return
+		}
+	}
+	child := &errorTreeNode{name: path[0]}
+	n.children = append(n.children, child)
+	child.insert(path[1:], errs)
+}
+
+// render writes the errors and children of the node rendered with the catalog, indented to the provided depth.
+func (n *errorTreeNode) render(errorBuffer *bytes.Buffer, depth int, catalog validator.Catalog) {
+	indent := strings.Repeat("\t", depth)
+	for _, err := range n.errors {
+		fmt.Fprintf(errorBuffer, "\n%s%s", indent, validator.LocalizeCatalogError(err, catalog))
+	}
+	for _, child := range n.children {
+		fmt.Fprintf(errorBuffer, "\n%s%s:", indent, child.name)
+		child.render(errorBuffer, depth+1, catalog)
+	}
+}
+
+// splitFieldPath splits a field path like "Detail.Items[2]" into its segments: "Detail", "Items" and "[2]".
+// The text in square brackets is a single segment, so a map key like "Hosts[a.example.com]" is not split at its dots.
+func splitFieldPath(path string) []string {
+	segments := []string{}
+	start := 0
+	inBrackets := false
+	for i := 0; i < len(path); i++ {
+		switch {
+		case inBrackets:
+			if path[i] == ']' {
+				segments = append(segments, path[start:i+1])
+				start = i + 1
+				inBrackets = false
+			}
+		case path[i] == '.':
+			if i > start {
+				segments = append(segments, path[start:i])
+			}
+			start = i + 1
+		case path[i] == '[':
+			if i > start {
+				segments = append(segments, path[start:i])
+			}
+			start = i
+			inBrackets = true
+		}
+	}
+	if start < len(path) {
+		segments = append(segments, path[start:])
+	}
+	return segments
+}
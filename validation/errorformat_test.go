@@ -0,0 +1,153 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+type FormatDetail struct {
+	Name string `validate:"string,max=3"`
+}
+
+type FormatItem struct {
+	Name   string       `validate:"string,min=5"`
+	Age    int          `validate:"int,max=150"`
+	Scores []int        `validate:"[]int,max=10"`
+	Detail FormatDetail `validate:"struct"`
+}
+
+func TestErrorsInDeclarationOrder(t *testing.T) {
+	testValue := FormatItem{
+		Name:   "abc",
+		Age:    200,
+		Scores: []int{1, 20},
+		Detail: FormatDetail{
+			Name: "abcd",
+		},
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("Name, Age, Scores and Detail should have failed validation")
+	}
+	expected := []string{"Name", "Age", "Scores[1]", "Detail.Name"}
+	for i := 0; i < 10; i++ {
+		if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+			t.Fatalf("the errors should be in the order %v but were in the order %v", expected, keys)
+		}
+	}
+	if validationError.DataType != "FormatItem" {
+		t.Errorf("DataType should be FormatItem but was %s", validationError.DataType)
+	}
+}
+
+func TestErrorFormats(t *testing.T) {
+	testValue := FormatItem{
+		Name:   "abc",
+		Age:    200,
+		Scores: []int{1, 20},
+		Detail: FormatDetail{
+			Name: "abcd",
+		},
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("Name, Age, Scores and Detail should have failed validation")
+	}
+	nameError := validationError.Errors["Name"][0].Error()
+	ageError := validationError.Errors["Age"][0].Error()
+	scoreError := validationError.Errors["Scores[1]"][0].Error()
+	detailError := validationError.Errors["Detail.Name"][0].Error()
+	expectedMultiLine := "FormatItem validation failed:" +
+		"\n\tName: " + nameError +
+		"\n\tAge: " + ageError +
+		"\n\tScores[1]: " + scoreError +
+		"\n\tDetail.Name: " + detailError
+	if multiLine := validationError.Error(); multiLine != expectedMultiLine {
+		t.Errorf("the default format should be multi line:\n%s\nbut was:\n%s", expectedMultiLine, multiLine)
+	}
+	expectedSingleLine := "FormatItem validation failed: Name: " + nameError +
+		"; Age: " + ageError +
+		"; Scores[1]: " + scoreError +
+		"; Detail.Name: " + detailError
+	if singleLine := validationError.Render(FormatSingleLine); singleLine != expectedSingleLine {
+		t.Errorf("the single line format should be:\n%s\nbut was:\n%s", expectedSingleLine, singleLine)
+	}
+	expectedTree := "FormatItem validation failed:" +
+		"\n\tName:\n\t\t" + nameError +
+		"\n\tAge:\n\t\t" + ageError +
+		"\n\tScores:\n\t\t[1]:\n\t\t\t" + scoreError +
+		"\n\tDetail:\n\t\tName:\n\t\t\t" + detailError
+	if tree := validationError.Render(FormatTree); tree != expectedTree {
+		t.Errorf("the tree format should be:\n%s\nbut was:\n%s", expectedTree, tree)
+	}
+}
+
+func TestWithErrorFormat(t *testing.T) {
+	testValue := FormatItem{Name: "abc", Age: 200}
+	validationError := New(WithErrorFormat(FormatSingleLine)).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("Name and Age should have failed validation")
+	}
+	if validationError.Error() != validationError.Render(FormatSingleLine) {
+		t.Error("the engine error format should be used by Error", validationError.Error())
+	}
+}
+
+func TestSplitFieldPath(t *testing.T) {
+	expected := []string{"Detail", "Items", "[2]", "[0]", "Name"}
+	if segments := splitFieldPath("Detail.Items[2][0].Name"); !reflect.DeepEqual(segments, expected) {
+		t.Errorf("the segments should be %v but were %v", expected, segments)
+	}
+	expected = []string{"Hosts", "[a.example.com]", "Port"}
+	if segments := splitFieldPath("Hosts[a.example.com].Port"); !reflect.DeepEqual(segments, expected) {
+		t.Errorf("a map key with dots should be a single segment, the segments should be %v but were %v", expected, segments)
+	}
+}
+
+func TestTreeFormatMapKeyWithDot(t *testing.T) {
+	validationError := ValidateStructWithTag(struct {
+		Hosts map[string]int `validate:"values=int,max=10"`
+	}{
+		Hosts: map[string]int{"a.example.com": 20},
+	})
+	if validationError == nil {
+		t.Fatal("the port for a.example.com should have failed validation")
+	}
+	hostError := validationError.Errors["Hosts[a.example.com]"][0].Error()
+	expected := "value validation failed:\n\tHosts:\n\t\t[a.example.com]:\n\t\t\t" + hostError
+	if tree := validationError.Render(FormatTree); tree != expected {
+		t.Errorf("the tree format should be:\n%s\nbut was:\n%s", expected, tree)
+	}
+}
+
+func TestRenderLocale(t *testing.T) {
+	engine := New(WithCatalog("de", validator.MapCatalog{
+		validator.ValidationFailedKey: "Validierung von {type} fehlgeschlagen:",
+		validator.CodeMax:             "{field} darf höchstens {param} sein",
+	}))
+	validationError := engine.ValidateStructWithTag(struct {
+		Age int `validate:"int,max=150"`
+	}{
+		Age: 200,
+	})
+	expected := "Validierung von value fehlgeschlagen:\n\tAge: Age darf höchstens 150 sein"
+	if rendered := validationError.RenderLocale(FormatMultiLine, "de"); rendered != expected {
+		t.Errorf("the rendered error should be:\n%s\nbut was:\n%s", expected, rendered)
+	}
+	if messages := validationError.Localize("de"); messages["Age"][0] != "Age darf höchstens 150 sein" {
+		t.Error("Localize should render the Age error in German", messages)
+	}
+	if problem := validationError.ProblemLocale("de"); problem.InvalidParams[0].Reason != "Age darf höchstens 150 sein" {
+		t.Error("ProblemLocale should render the Age error in German", problem.InvalidParams)
+	}
+	otherError := ValidateStructWithTag(struct {
+		Age int `validate:"int,max=150"`
+	}{
+		Age: 200,
+	})
+	if rendered := otherError.RenderLocale(FormatMultiLine, "de"); rendered != otherError.Error() {
+		t.Errorf("the catalog of an Engine should not be used by other Engines:\n%s", rendered)
+	}
+}
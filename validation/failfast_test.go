@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type FailFastItem struct {
+	Name     string   `validate:"string,min=3"`
+	Age      int      `validate:"int,min=18"`
+	Tags     []string `validate:"[]string,minlen=3,unique,max=3"`
+	Password string   `validate:"string,min=8"`
+	Confirm  string   `validate:"string,min=8,eqfield=Password"`
+}
+
+func TestWithFailFast(t *testing.T) {
+	testValue := FailFastItem{
+		Name:     "ab",
+		Age:      3,
+		Tags:     []string{"long tag", "long tag"},
+		Password: "secret",
+		Confirm:  "secrets",
+	}
+	validationError := New(WithFailFast()).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("Name should have failed validation")
+	}
+	if len(validationError.Errors) != 1 || len(validationError.Errors["Name"]) != 1 {
+		t.Error("only the first error should be reported", validationError.Error())
+	}
+	if !validationError.Truncated {
+		t.Error("the ValidationError should be truncated")
+	}
+	if !strings.HasSuffix(validationError.Error(), "validation stopped early, other fields may also be invalid") {
+		t.Error("the error message should say validation stopped early", validationError.Error())
+	}
+	body, err := json.Marshal(validationError)
+	if err != nil || !strings.Contains(string(body), `"truncated":true`) {
+		t.Errorf("the JSON should say the error is truncated: %s %v", body, err)
+	}
+	if problem := validationError.Problem(); !problem.Truncated {
+		t.Error("the problem should say the error is truncated")
+	}
+}
+
+func TestWithMaxErrors(t *testing.T) {
+	testValue := FailFastItem{
+		Name:     "ab",
+		Age:      3,
+		Tags:     []string{"long tag", "long tag"},
+		Password: "secret",
+		Confirm:  "secrets",
+	}
+	validationError := New(WithMaxErrors(3)).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("every field should have failed validation")
+	}
+	if count := len(validationError.Unwrap()); count != 3 || !validationError.Truncated {
+		t.Errorf("3 errors should be reported and the error truncated, but got %d errors: %s", count, validationError.Error())
+	}
+	validationError = New(WithMaxErrors(100)).ValidateStructWithTag(testValue)
+	if validationError == nil || validationError.Truncated {
+		t.Error("the ValidationError should not be truncated when the maximum is not reached")
+	}
+}
+
+func TestWithMaxErrorsExactCount(t *testing.T) {
+	type exactItem struct {
+		Name string `validate:"string,min=3"`
+		Age  int    `validate:"int,min=18"`
+	}
+	engine := New(WithMaxErrors(1))
+	validationError := engine.ValidateStructWithTag(exactItem{Name: "abcd", Age: 3})
+	if validationError == nil {
+		t.Fatal("Age should have failed validation")
+	}
+	if validationError.Truncated || strings.Contains(validationError.Error(), "validation stopped early") {
+		t.Errorf("the ValidationError should not be truncated when every value was validated and no error was dropped: %s", validationError.Error())
+	}
+	validationError = engine.ValidateStructWithTag(exactItem{Name: "ab", Age: 3})
+	if validationError == nil || !validationError.Truncated {
+		t.Errorf("the ValidationError should be truncated when Age is skipped after the maximum is reached: %v", validationError)
+	}
+}
+
+func TestWithFieldFailFast(t *testing.T) {
+	testValue := FailFastItem{
+		Name:     "abc",
+		Age:      18,
+		Tags:     []string{"long tag", "long tag"},
+		Password: "secret",
+		Confirm:  "secrets",
+	}
+	validationError := New(WithFieldFailFast()).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("Tags, Password and Confirm should have failed validation")
+	}
+	for _, key := range []string{"Tags", "Confirm"} {
+		if errs := validationError.Errors[key]; len(errs) != 1 {
+			t.Errorf("%s should have one error but had %d: %v", key, len(errs), errs)
+		}
+	}
+	if _, ok := validationError.Errors["Tags[0]"]; ok {
+		t.Error("the elements of Tags should not be validated after a collection rule fails")
+	}
+	if validationError.Truncated {
+		t.Error("WithFieldFailFast should not truncate the ValidationError")
+	}
+}
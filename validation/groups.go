@@ -0,0 +1,146 @@
+package validation
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+const (
+	// DefaultGroup is the validation group of every field and rule that is not assigned to a group. It is the only group validated by ValidateStructWithTag.
+	DefaultGroup = "default"
+	// GroupsTagKey is the struct tag key that assigns a field to validation groups, as a comma separated list of group names.
+	GroupsTagKey = "groups"
+
+	// groupPrefix starts the name of a validation group in tag data.
+	groupPrefix = "@"
+	// allGroupsName is the name in a groupSet that contains every group, used to check the tag data of every field and rule.
+	allGroupsName = "*"
+)
+
+/*
+	groupSet contains the validation groups of a validation run.
+
+	A field is validated when it is in one of the groups. A field is in the groups listed in its groups tag and in the groups named in its tag data with a bare @group item:
+
+		`validate:"uuid,required" groups:"update"`
+		`validate:"uuid,required,@update"`
+
+	A single rule is assigned to groups by appending them to the rule, so the rule is only applied when one of its groups is validated:
+
+		`validate:"uuid,required@update"`
+
+	A field that is not assigned to a group is in the DefaultGroup and in every group named by its rules.
+*/
+type groupSet map[string]bool
+
+var (
+	// defaultGroups is the groupSet used when no groups are requested.
+	defaultGroups = newGroupSet(DefaultGroup)
+	// allGroups is the groupSet containing every group.
+	allGroups = newGroupSet(allGroupsName)
+)
+
+// newGroupSet creates a groupSet containing the provided groups, or the DefaultGroup when no groups are provided.
+func newGroupSet(groups ...string) groupSet {
+	if len(groups) == 0 {
+		return groupSet{DefaultGroup: true}
+	}
+	gs := make(groupSet, len(groups))
+	for _, group := range groups {
+		gs[group] = true
+	}
+	return gs
+}
+
+// key returns the sorted group names joined by commas, used in the plan cache key.
+func (gs groupSet) key() string {
+	groups := make([]string, 0, len(gs))
+	for group := range gs {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return strings.Join(groups, ",")
+}
+
+// containsAny returns true when any of the provided groups is in the groupSet.
+func (gs groupSet) containsAny(groups []string) bool {
+	if gs[allGroupsName] {
+		return true
+	}
+	for _, group := range groups {
+		if gs[group] {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+	filterGroupItems removes the group information from the tag items.
+
+	It returns the items that apply to the groupSet without their group suffixes, the field groups named by bare @group items, and every group named in the items.
+*/
+func (gs groupSet) filterGroupItems(items []string) ([]string, []string, []string) {
+	activeItems := make([]string, 0, len(items))
+	fieldGroups := []string{}
+	ruleGroups := []string{}
+	for _, item := range items {
+		parts := splitGroups(item)
+		if parts[0] == "" {
+			fieldGroups = append(fieldGroups, parts[1:]...)
+			continue
+		}
+		if len(parts) == 1 {
+			activeItems = append(activeItems, item)
+			continue
+		}
+		ruleGroups = append(ruleGroups, parts[1:]...)
+		if gs.containsAny(parts[1:]) {
+			activeItems = append(activeItems, parts[0])
+		}
+	}
+	return activeItems, fieldGroups, ruleGroups
+}
+
+// splitGroups splits a tag item at each @ that is not quoted or escaped, so a quoted value like pattern='a@b' is not read as a validation group.
+func splitGroups(item string) []string {
+	tagItems, err := validator.SplitTagItems(item, []rune(groupPrefix)[0])
+	if err != nil {
+		return []string{item}
+	}
+	parts := make([]string, 0, len(tagItems))
+	for _, tagItem := range tagItems {
+		parts = append(parts, tagItem.Text)
+	}
+	return parts
+}
+
+// includesField returns true when a field with the provided groups is validated for the groupSet.
+// fieldGroups are the groups the field is assigned to, and ruleGroups are the groups named by its rules.
+func (gs groupSet) includesField(fieldGroups, ruleGroups []string) bool {
+	if len(fieldGroups) > 0 {
+		return gs.containsAny(fieldGroups)
+	}
+	return gs[DefaultGroup] || gs.containsAny(ruleGroups)
+}
+
+// parseGroupsTag returns the group names in the value of a groups tag.
+func parseGroupsTag(tag string) []string {
+	groups := []string{}
+	for _, group := range strings.Split(tag, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// ValidateGroups validates an input struct based on its validation tag data, applying only the fields and rules in the provided validation groups.
+// Fields that are not assigned to a group are in the DefaultGroup, so it has to be included to validate them along with the other groups. When no groups are provided the DefaultGroup is validated.
+func (e *Engine) ValidateGroups(s interface{}, groups ...string) *ValidationError {
+	validationError, _ := e.validate(e.newValidationState(context.Background(), s, newGroupSet(groups...)), s)
+	return validationError
+}
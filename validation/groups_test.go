@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+type Account struct {
+	ID       string `validate:"string,required@update,excluded_if=$.Mode create"`
+	Mode     string `validate:"string"`
+	Name     string `validate:"string,required,min=3"`
+	Password string `validate:"string,required,min=8" groups:"create"`
+	Reason   string `validate:"string,required,@update,@delete,msg=a reason like a@b is required"`
+}
+
+func TestValidateGroups(t *testing.T) {
+	testValue := Account{ID: "", Mode: "update", Name: "ab"}
+	tests := []struct {
+		name     string
+		groups   []string
+		expected []string
+	}{
+		{"no groups validates the default group", nil, []string{"Name"}},
+		{"default group", []string{DefaultGroup}, []string{"Name"}},
+		{"update group without the default group", []string{"update"}, []string{"ID", "Reason"}},
+		{"update group with the default group", []string{DefaultGroup, "update"}, []string{"ID", "Name", "Reason"}},
+		{"create group", []string{"create"}, []string{"Password"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validationError := ValidateGroups(testValue, test.groups...)
+			if validationError == nil {
+				t.Fatal("testValue should have failed validation")
+			}
+			if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, test.expected) {
+				t.Errorf("the error keys should be %v but were %v", test.expected, keys)
+			}
+		})
+	}
+}
+
+func TestValidateGroupsRuleAndMessage(t *testing.T) {
+	validationError := ValidateGroups(Account{Mode: "create", ID: "set"}, "update", "delete")
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	if errs := validationError.Errors["Reason"]; len(errs) != 1 || errs[0].Error() != "a reason like a@b is required" {
+		t.Error("the message should not be split on the @", errs)
+	}
+	if errs := validationError.Errors["ID"]; len(errs) != 1 || !errors.Is(errs[0], validator.ErrExcluded) {
+		t.Error("the ID should only fail the excluded_if rule, because it is set", errs)
+	}
+	if validationError := ValidateStructWithTag(Account{Name: "Calvin"}); validationError != nil {
+		t.Error("the default group should not apply the rules of other groups", validationError.Error())
+	}
+}
+
+func TestGroupPlansAreCachedSeparately(t *testing.T) {
+	e := New()
+	structType := reflect.TypeOf(Account{})
+	defaultPlan := e.getStructPlan(structType, defaultGroups)
+	updatePlan := e.getStructPlan(structType, newGroupSet("update"))
+	if defaultPlan == updatePlan {
+		t.Error("each set of groups should have its own plan")
+	}
+	if updatePlan != e.getStructPlan(structType, newGroupSet("update")) {
+		t.Error("the plan for a set of groups should be cached")
+	}
+}
@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/calvine/simplevalidation/validation/validationparams"
+	"github.com/calvine/simplevalidation/validator"
+)
+
+const (
+	// mapValidatorName is the reserved validator name used in tag data for the optional first section of a map tag.
+	mapValidatorName = "map"
+	// mapKeysPrefix starts the section of a map tag with the tag data for the map keys.
+	mapKeysPrefix = "keys="
+	// mapValuesPrefix starts the section of a map tag with the tag data for the map values.
+	mapValuesPrefix = "values="
+	// mapSectionSeparator separates the sections of a map tag.
+	mapSectionSeparator = ';'
+
+	unknownMapSectionErrorTemplate = "unknown map tag section %q"
+)
+
+/*
+	isMapTag returns true when the tag data describes how to validate a map.
+
+	A map tag is made of sections separated by semicolons. The keys= section holds the tag data for the map keys and the values= section holds the tag data for the map values.
+	An optional first section starting with map holds the parameters for the map itself:
+
+		`validate:"map,required;keys=string,max=20;values=int,min=0"`
+
+	The map section may also hold collection rules, see collectionRules for more information.
+*/
+func isMapTag(tag string) bool {
+	if tag == mapValidatorName || strings.HasPrefix(tag, mapValidatorName+",") || strings.HasPrefix(tag, mapValidatorName+string(mapSectionSeparator)) {
+		return true
+	}
+	sections, err := validator.SplitTagItems(tag, mapSectionSeparator)
+	if err != nil {
+		return false
+	}
+	for _, section := range sections {
+		if strings.HasPrefix(section.Text, mapKeysPrefix) || strings.HasPrefix(section.Text, mapValuesPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileMapTag builds the fieldPlan for a field with map tag data. The keys and values sections are compiled like the tag data of any other field.
+func (e *Engine) compileMapTag(name, tag string, byCode map[string]string, groups groupSet) fieldPlan {
+	plan := fieldPlan{
+		messages: fieldMessages{byCode: byCode},
+		isMap:    true,
+	}
+	sections, err := validator.SplitTagItems(tag, mapSectionSeparator)
+	if err != nil {
+		plan.err = newTagSyntaxError(mapValidatorName, name, tag, err)
+		return plan
+	}
+	for i, tagItem := range sections {
+		section := tagItem.Text
+		switch {
+		case strings.HasPrefix(section, mapKeysPrefix):
+			keys := e.compileTag(name, strings.TrimPrefix(section, mapKeysPrefix), tagItem.Column+len(mapKeysPrefix), byCode, groups)
+			plan.mapKeys = &keys
+			plan.ruleGroups = append(plan.ruleGroups, keys.ruleGroups...)
+		case strings.HasPrefix(section, mapValuesPrefix):
+			values := e.compileTag(name, strings.TrimPrefix(section, mapValuesPrefix), tagItem.Column+len(mapValuesPrefix), byCode, groups)
+			plan.mapValues = &values
+			plan.ruleGroups = append(plan.ruleGroups, values.ruleGroups...)
+		case i == 0 && (section == mapValidatorName || strings.HasPrefix(section, mapValidatorName+",")):
+			mapItems, err := splitTagItems(section, tagItem.Column, ',')
+			if err != nil {
+				plan.err = newTagSyntaxError(mapValidatorName, name, tag, err)
+				continue
+			}
+			items, message := extractMessageOption(mapItems[1:])
+			items, fieldGroups, ruleGroups := groups.filterGroupItems(items)
+			plan.groups = fieldGroups
+			plan.ruleGroups = append(plan.ruleGroups, ruleGroups...)
+			items, collection, err := extractCollectionRules(items)
+			if err != nil {
+				plan.err = validator.NewFieldError(mapValidatorName, validator.CodeBadTag, name, tag, nil, "").WithDetail("error", err.Error())
+			}
+			_, presence, err := extractPresenceOptions(items)
+			if err != nil {
+				plan.err = validator.NewFieldError(mapValidatorName, validator.CodeBadTag, name, tag, nil, "").WithDetail("error", err.Error())
+			}
+			plan.required = presence.required
+			plan.omitEmpty = presence.omitEmpty
+			plan.nullable = presence.nullable
+			plan.collection = collection
+			plan.messages.all = message
+		default:
+			plan.err = validator.NewFieldError(mapValidatorName, validator.CodeBadTag, name, tag, nil, "").WithDetail("error", fmt.Sprintf(unknownMapSectionErrorTemplate, section))
+		}
+	}
+	return plan
+}
+
+// validateMap validates the keys and values of a map with the keys and values plans of the field, naming each entry in the format "name[key]".
+func (e *Engine) validateMap(validationInfo validationparams.ValidationParams, field *fieldPlan, state *validationState) []error {
+	value := reflect.ValueOf(validationInfo.Value)
+	if value.Kind() != reflect.Map {
+		return []error{validator.NewFieldError(mapValidatorName, validator.CodeType, validationInfo.Name, "", validationInfo.Value, "").WithDetail("type", value.Kind().String())}
+	}
+	// collection rules are evaluated on the map before its entries are validated, so their errors come first.
+	collectionErrors := state.limitFieldErrors(field.collection.check(value, validationInfo.Name))
+	state.addErrors(validationInfo.Name, field.messages.apply(collectionErrors)...)
+	if state.fieldFailFast && len(collectionErrors) > 0 {
+		return nil
+	}
+	for _, key := range sortedMapKeys(value) {
+		if state.stopped() {
+			return nil
+		}
+		entryName := fmt.Sprintf("%s[%v]", validationInfo.Name, key.Interface())
+		for _, entry := range []struct {
+			plan  *fieldPlan
+			value reflect.Value
+		}{{field.mapKeys, key}, {field.mapValues, value.MapIndex(key)}} {
+			if entry.plan == nil || (entry.plan.err != nil && entry.plan.fieldValidator == nil) {
+				continue
+			}
+			e.performFieldValidation(validationparams.ValidationParams{
+				ArrayDepth:     entry.plan.arrayDepth,
+				FieldValidator: entry.plan.fieldValidator,
+				Name:           entryName,
+				Required:       entry.plan.required,
+				OmitEmpty:      entry.plan.omitEmpty,
+				Nullable:       entry.plan.nullable,
+				StructDepth:    validationInfo.StructDepth,
+				Value:          entry.value.Interface(),
+			}, entry.plan, state)
+		}
+	}
+	return nil
+}
+
+// sortedMapKeys returns the keys of a map sorted by their formatted value. Map iteration order is random, so the keys are sorted to keep the order of the errors stable.
+func sortedMapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
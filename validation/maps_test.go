@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+type MapItem struct {
+	Limits  map[string]int        `validate:"keys=string,max=5;values=int,min=0"`
+	Details map[string]OtherThing `validate:"map,required;values=struct"`
+	Scores  *map[int][]int        `validate:"values=[]int,max=10"`
+}
+
+func TestMapValidation(t *testing.T) {
+	scores := map[int][]int{1: {1, 2}, 2: {3, 11}}
+	testValue := MapItem{
+		Limits: map[string]int{"foo": 1, "bar": -1, "toolong": 3},
+		Details: map[string]OtherThing{
+			"a": {ID: 5, Description: "a long enough description"},
+			"b": {ID: 5, Description: "too short"},
+		},
+		Scores: &scores,
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("the invalid map keys and values should have failed validation")
+	}
+	expected := []string{"Limits[bar]", "Limits[toolong]", "Details[b].Description", "Scores[2][1]"}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("the error keys should be %v but were %v", expected, keys)
+	}
+	if !errors.Is(validationError.Errors["Limits[bar]"][0], validator.ErrMin) {
+		t.Error("the Limits[bar] value error should be a min error", validationError.Errors["Limits[bar]"])
+	}
+	if !errors.Is(validationError.Errors["Limits[toolong]"][0], validator.ErrMax) {
+		t.Error("the Limits[toolong] key error should be a max error", validationError.Errors["Limits[toolong]"])
+	}
+}
+
+func TestMapValidationRequired(t *testing.T) {
+	validationError := ValidateStructWithTag(MapItem{})
+	if validationError == nil {
+		t.Fatal("the nil Details map should have failed validation")
+	}
+	if len(validationError.Errors) != 1 || !errors.Is(validationError.Errors["Details"][0], validator.ErrRequired) {
+		t.Error("only Details should have failed validation with a required error", validationError.Error())
+	}
+}
+
+func TestMapTagErrors(t *testing.T) {
+	testValue := struct {
+		Limits map[string]int `validate:"keys=string;nope=int"`
+		Age    int            `validate:"values=int"`
+	}{Limits: map[string]int{"a": 1}}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("the bad map tags should have failed validation")
+	}
+	if !errors.Is(validationError.Errors["value"][0], validator.ErrBadTag) {
+		t.Error("the unknown map section should be a bad tag error", validationError.Error())
+	}
+	if !errors.Is(validationError.Errors["Age"][0], validator.ErrType) {
+		t.Error("map tag data on an int should be a type error", validationError.Error())
+	}
+}
+
+func TestMapIsReserved(t *testing.T) {
+	if err := New().RegisterValidator(mapValidatorName, newPhoneValidator); !errors.Is(err, ErrInvalidRegistration) {
+		t.Errorf("registering %s should fail with ErrInvalidRegistration but got %v", mapValidatorName, err)
+	}
+}
@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+const (
+	// messageOption is the tag option that overrides the message of every error for a field, for instance `validate:"postalcode,required,msg=Please enter your postal code"`.
+	messageOption = "msg"
+	// messageTagSuffix is appended to the Engine tag key to get the companion tag key containing per rule messages, for instance `validate_msg:"required=Please enter your postal code;min=..."`.
+	messageTagSuffix = "_msg"
+)
+
+/*
+	fieldMessages contains the custom messages declared for a field.
+
+	Messages are templates that can use the same named parameters as a validator.Catalog template, like {field}, {param} and {value}.
+*/
+type fieldMessages struct {
+	// all is the message from the msg tag option, used for every error of the field that has no message in byCode.
+	all string
+	// byCode contains the messages from the companion message tag for each rule code.
+	byCode map[string]string
+}
+
+// extractMessageOption removes the msg option from the tag items, returning the remaining items and the message.
+// The message may be quoted, so it can contain commas: msg='Please, enter a name'.
+func extractMessageOption(items []string) ([]string, string) {
+	message := ""
+	remainingItems := make([]string, 0, len(items))
+	for _, item := range items {
+		// the items were checked when they were split, so the option can always be read.
+		if option, _ := validator.ParseOption(item); option.Key == messageOption && option.HasValue {
+			message = option.Value
+			continue
+		}
+		remainingItems = append(remainingItems, item)
+	}
+	return remainingItems, message
+}
+
+/*
+	parseMessageTag reads the rule code and message pairs from the companion message tag, which are in the format "code=message;code=message".
+
+	The tag is split with the same rules as the validation tag data, so a message can be quoted to contain a semicolon: min='At least {param} characters; more is better'.
+	Items that are not pairs are ignored, and a *validator.TagSyntaxError is returned when the tag cannot be split.
+*/
+func parseMessageTag(tag string) (map[string]string, error) {
+	if tag == "" {
+		return nil, nil
+	}
+	items, err := splitTagItems(tag, 1, ';')
+	if err != nil {
+		return nil, err
+	}
+	messages := map[string]string{}
+	for _, item := range items {
+		// the items were checked when they were split, so the option can always be read.
+		if option, _ := validator.ParseOption(item); option.HasValue {
+			messages[strings.TrimSpace(option.Key)] = option.Value
+		}
+	}
+	return messages, nil
+}
+
+// isEmpty returns true when no custom messages are declared for the field.
+func (fm fieldMessages) isEmpty() bool {
+	return fm.all == "" && len(fm.byCode) == 0
+}
+
+// apply returns the errors with the custom message for their rule code set on each *validator.FieldError.
+// The original errors are not modified, since a custom validator may return the same error for more than one value.
+func (fm fieldMessages) apply(errs []error) []error {
+	if fm.isEmpty() {
+		return errs
+	}
+	messagedErrors := make([]error, 0, len(errs))
+	for _, err := range errs {
+		var fieldError *validator.FieldError
+		if !errors.As(err, &fieldError) {
+			messagedErrors = append(messagedErrors, err)
+			continue
+		}
+		template, ok := fm.byCode[fieldError.Code]
+		if !ok {
+			template = fm.all
+		}
+		if template == "" {
+			messagedErrors = append(messagedErrors, err)
+			continue
+		}
+		messagedError := *fieldError
+		messagedError.Message = validator.RenderTemplate(template, fieldError.Params())
+		messagedErrors = append(messagedErrors, &messagedError)
+	}
+	return messagedErrors
+}
@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+type MessageItem struct {
+	PostalCode string   `validate:"postalcode,required" validate_msg:"required=Please enter your postal code;invalid={value} is not a valid postal code"`
+	Name       string   `validate:"string,min=3,msg=Please enter a name of at least {param} characters"`
+	Scores     []int    `validate:"[]int,max=10,msg={field} must be at most {param}"`
+	Nickname   *string  `validate:"string,required,msg=Please enter a nickname"`
+	Tags       []string `validate:"[]string,max=3"`
+}
+
+func TestCustomMessages(t *testing.T) {
+	testValue := MessageItem{
+		Name:   "ab",
+		Scores: []int{1, 11},
+		Tags:   []string{"long tag"},
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	expected := map[string]string{
+		"PostalCode": "Please enter your postal code",
+		"Name":       "Please enter a name of at least 3 characters",
+		"Scores[1]":  "Scores[1] must be at most 10",
+		"Nickname":   "Please enter a nickname",
+	}
+	for key, message := range expected {
+		errs, ok := validationError.Errors[key]
+		if !ok {
+			t.Errorf("validationError.Errors should contain key '%s'", key)
+		} else if errs[0].Error() != message {
+			t.Errorf("the message for %s should be '%s' but was '%s'", key, message, errs[0].Error())
+		}
+	}
+	if validationError.Errors["Tags[0]"][0].Error() == "" || !errors.Is(validationError.Errors["Tags[0]"][0], validator.ErrMax) {
+		t.Error("Tags[0] has no custom message so it should keep the catalog message and its code", validationError.Errors["Tags[0]"])
+	}
+	if !errors.Is(validationError.Errors["PostalCode"][0], validator.ErrRequired) {
+		t.Error("a custom message should not change the error code")
+	}
+}
+
+func TestCustomMessagePerRule(t *testing.T) {
+	testValue := MessageItem{
+		PostalCode: "abc",
+		Name:       "Calvin",
+	}
+	s := "nick"
+	testValue.Nickname = &s
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	if message := validationError.Errors["PostalCode"][0].Error(); message != "abc is not a valid postal code" {
+		t.Error("the invalid message should be used for an invalid postal code:", message)
+	}
+}
+
+func TestParseMessageTag(t *testing.T) {
+	messages, err := parseMessageTag("required=Please enter a value; min=Too short;notapair")
+	if err != nil || len(messages) != 2 || messages["required"] != "Please enter a value" || messages["min"] != "Too short" {
+		t.Error("the message tag was not parsed correctly", messages, err)
+	}
+	messages, err = parseMessageTag(`min='need; more';max=at most {param}\; really`)
+	if err != nil || len(messages) != 2 || messages["min"] != "need; more" || messages["max"] != "at most {param}; really" {
+		t.Error("quoted and escaped semicolons should be part of the message", messages, err)
+	}
+	testValue := struct {
+		Name string `validate:"string,min=3" validate_msg:"min='need; more"`
+	}{Name: "ab"}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil || !errors.Is(validationError, validator.ErrBadTag) {
+		t.Errorf("an unterminated quote in the message tag should be a bad tag error: %v", validationError)
+	}
+}
@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+)
+
+/*
+	NameFunc returns the name used for a struct field in validation errors.
+
+	When a NameFunc returns an empty string the Go field name is used instead.
+	Nested struct fields and array elements are still joined into a path with "." and "[index]", for instance "detail.items[2].name".
+*/
+type NameFunc func(field reflect.StructField) string
+
+// GoFieldName is the default NameFunc, which uses the Go field name.
+func GoFieldName(field reflect.StructField) string {
+	return field.Name
+}
+
+// JSONFieldName is a NameFunc that uses the name from the json tag of the field, so error keys match the JSON payload.
+func JSONFieldName(field reflect.StructField) string {
+	return TagFieldName("json")(field)
+}
+
+// TagFieldName returns a NameFunc that uses the name from the provided tag key, for instance "form" or "yaml".
+// The name is the tag data up to the first comma, and the Go field name is used when the tag is missing, empty or "-".
+func TagFieldName(tagKey string) NameFunc {
+	return func(field reflect.StructField) string {
+		name := field.Tag.Get(tagKey)
+		if commaIndex := strings.Index(name, ","); commaIndex != -1 {
+			name = name[:commaIndex]
+		}
+		if name == "-" {
+			return ""
+		}
+		return name
+	}
+}
+
+// WithNameFunc sets the NameFunc used to name struct fields in validation errors. The default is GoFieldName.
+func WithNameFunc(nameFunc NameFunc) Option {
+	return func(e *Engine) {
+		e.nameFunc = nameFunc
+	}
+}
+
+// fieldName returns the name used for the struct field in validation errors, falling back to the Go field name.
+func (e *Engine) fieldName(field reflect.StructField) string {
+	if e.nameFunc != nil {
+		if name := e.nameFunc(field); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+)
+
+type NamedDetail struct {
+	Name string `json:"name" validate:"string,max=3"`
+}
+
+type NamedItem struct {
+	FirstName string       `json:"first_name,omitempty" validate:"string,min=3"`
+	Ignored   string       `json:"-" validate:"string,min=3"`
+	Untagged  string       `validate:"string,min=3"`
+	Detail    NamedDetail  `json:"detail" validate:"struct"`
+	Scores    []int        `json:"scores" validate:"[]int,max=10"`
+	Details   *NamedDetail `form:"details_form" json:"details" validate:"struct"`
+}
+
+func TestJSONFieldName(t *testing.T) {
+	testValue := NamedItem{
+		FirstName: "ab",
+		Ignored:   "ab",
+		Untagged:  "ab",
+		Detail:    NamedDetail{Name: "abcd"},
+		Scores:    []int{1, 11},
+		Details:   &NamedDetail{Name: "abcd"},
+	}
+	validationError := New(WithNameFunc(JSONFieldName)).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("every field of testValue should have failed validation")
+	}
+	expected := []string{"first_name", "Ignored", "Untagged", "detail.name", "scores[1]", "details.name"}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("the error keys should be %v but were %v", expected, keys)
+	}
+	if fieldErrors := validationError.FieldErrors(); fieldErrors[3].Field != "detail.name" {
+		t.Errorf("the FieldError Field should be detail.name but was %s", fieldErrors[3].Field)
+	}
+}
+
+func TestTagFieldName(t *testing.T) {
+	testValue := NamedItem{
+		FirstName: "abc",
+		Ignored:   "abc",
+		Untagged:  "abc",
+		Details:   &NamedDetail{Name: "abcd"},
+	}
+	validationError := New(WithNameFunc(TagFieldName("form"))).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("Details.Name should have failed validation")
+	}
+	if _, ok := validationError.Errors["details_form.Name"]; !ok {
+		t.Error("validationError.Errors should contain key 'details_form.Name'", validationError.Error())
+	}
+}
+
+func TestCustomNameFunc(t *testing.T) {
+	upperName := func(field reflect.StructField) string {
+		if field.Name == "FirstName" {
+			return "FIRST"
+		}
+		return ""
+	}
+	testValue := NamedItem{
+		FirstName: "ab",
+		Ignored:   "abc",
+		Untagged:  "ab",
+	}
+	validationError := New(WithNameFunc(upperName)).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("FirstName and Untagged should have failed validation")
+	}
+	if _, ok := validationError.Errors["FIRST"]; !ok {
+		t.Error("validationError.Errors should contain key 'FIRST'", validationError.Error())
+	}
+	if _, ok := validationError.Errors["Untagged"]; !ok {
+		t.Error("an empty name should fall back to the Go field name", validationError.Error())
+	}
+}
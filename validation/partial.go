@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"context"
+	"strings"
+)
+
+/*
+	pathFilter selects the fields validated by ValidatePartial and ValidateExcept.
+
+	Each field path is split on dots into a tree of field names, so "Detail.Name" selects the Name field of the Detail struct.
+	A field is matched by its Go field name or by its name in validation errors. Slices, arrays and maps are transparent, so "Addresses.City" selects the City field of every element of Addresses.
+*/
+type pathFilter struct {
+	// except is true when the selected fields are skipped instead of validated.
+	except bool
+	// full is true when a path ends at the field, so every field below it is selected.
+	full bool
+	// children contains the filter for each field named below the field.
+	children map[string]*pathFilter
+}
+
+// newPathFilter builds the pathFilter for the provided field paths.
+func newPathFilter(paths []string, except bool) *pathFilter {
+	root := &pathFilter{except: except, children: map[string]*pathFilter{}}
+	for _, path := range paths {
+		node := root
+		for _, name := range strings.Split(path, ".") {
+			// indexes like Addresses[2] are ignored, because slices are transparent to the filter.
+			if index := strings.Index(name, "["); index >= 0 {
+				name = name[:index]
+			}
+			child, ok := node.children[name]
+			if !ok {
+				child = &pathFilter{except: except, children: map[string]*pathFilter{}}
+				node.children[name] = child
+			}
+			node = child
+		}
+		node.full = true
+	}
+	return root
+}
+
+// child returns the filter for the fields below the field, and false when the field is not validated. A nil filter validates every field.
+func (pf *pathFilter) child(field *fieldPlan) (*pathFilter, bool) {
+	if pf == nil {
+		return nil, true
+	}
+	child, ok := pf.children[field.goName]
+	if !ok {
+		child, ok = pf.children[field.name]
+	}
+	switch {
+	case !ok:
+		// the field is not named, so it is validated only when the paths name the fields to skip.
+		return nil, pf.except
+	case child.full:
+		// the path ends at the field, so it is either validated entirely or skipped entirely.
+		return nil, !pf.except
+	}
+	return child, true
+}
+
+// ValidatePartial validates an input struct based on its validation tag data, validating only the fields named by the provided dotted paths, for instance "Name" or "Detail.Name".
+// Naming a struct field validates every field of the struct.
+func (e *Engine) ValidatePartial(s interface{}, paths ...string) *ValidationError {
+	state := e.newValidationState(context.Background(), s, defaultGroups)
+	state.filter = newPathFilter(paths, false)
+	validationError, _ := e.validate(state, s)
+	return validationError
+}
+
+// ValidateExcept validates an input struct based on its validation tag data, skipping the fields named by the provided dotted paths, for instance "Name" or "Detail.Name".
+// Naming a struct field skips every field of the struct.
+func (e *Engine) ValidateExcept(s interface{}, paths ...string) *ValidationError {
+	state := e.newValidationState(context.Background(), s, defaultGroups)
+	state.filter = newPathFilter(paths, true)
+	validationError, _ := e.validate(state, s)
+	return validationError
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Profile struct {
+	Name      string       `json:"name" validate:"string,min=3"`
+	Age       int          `json:"age" validate:"int,min=18"`
+	Detail    NamedDetail  `json:"detail" validate:"struct"`
+	Addresses []Address    `json:"addresses" validate:"[]struct"`
+	Dates     StayDates    `json:"dates" validate:"struct"`
+	Tags      []string     `json:"tags" validate:"[]string,max=3"`
+	Optional  *NamedDetail `json:"optional" validate:"struct"`
+}
+
+func TestValidatePartial(t *testing.T) {
+	testValue := Profile{
+		Name:      "ab",
+		Age:       3,
+		Detail:    NamedDetail{Name: "abcd"},
+		Addresses: []Address{{City: "X", PostalCode: "bad"}},
+		Dates:     StayDates{Start: 2, End: 1},
+		Tags:      []string{"long tag"},
+		Optional:  &NamedDetail{Name: "abcd"},
+	}
+	tests := []struct {
+		name     string
+		paths    []string
+		expected []string
+	}{
+		{"top level fields", []string{"Name", "Tags"}, []string{"Name", "Tags[0]"}},
+		{"nested field", []string{"Detail.Name"}, []string{"Detail.Name"}},
+		{"whole struct", []string{"Dates"}, []string{"Dates"}},
+		{"field of slice elements", []string{"Addresses.City"}, []string{"Addresses[0].City"}},
+		{"indexed path", []string{"Addresses[0].PostalCode"}, []string{"Addresses[0].PostalCode"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validationError := ValidatePartial(testValue, test.paths...)
+			if validationError == nil {
+				t.Fatalf("%v should have failed validation", test.paths)
+			}
+			if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, test.expected) {
+				t.Errorf("the error keys should be %v but were %v", test.expected, keys)
+			}
+		})
+	}
+	validationError := New(WithNameFunc(JSONFieldName)).ValidatePartial(testValue, "age", "optional.name")
+	expected := []string{"age", "optional.name"}
+	if validationError == nil {
+		t.Error("age and optional.name should have failed validation when named by their error names")
+	} else if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("the error keys should be %v but were %v", expected, keys)
+	}
+	if validationError := ValidatePartial(testValue); validationError != nil {
+		t.Error("no fields should be validated when no paths are provided", validationError.Error())
+	}
+}
+
+func TestValidateExcept(t *testing.T) {
+	testValue := Profile{
+		Name:      "ab",
+		Age:       3,
+		Detail:    NamedDetail{Name: "abcd"},
+		Addresses: []Address{{City: "X", PostalCode: "bad"}},
+		Dates:     StayDates{Start: 2, End: 1},
+		Tags:      []string{"long tag"},
+		Optional:  &NamedDetail{Name: "abcd"},
+	}
+	validationError := ValidateExcept(testValue, "Name", "Detail.Name", "Addresses.City", "Dates.Start", "Tags", "Optional")
+	if validationError == nil {
+		t.Fatal("Age and Addresses[0].PostalCode should have failed validation")
+	}
+	// the Dates struct does not validate itself because one of its fields is skipped.
+	expected := []string{"Age", "Addresses[0].PostalCode"}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("the error keys should be %v but were %v", expected, keys)
+	}
+}
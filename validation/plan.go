@@ -0,0 +1,272 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+/*
+	structPlan is the compiled validation instructions for a struct type.
+
+	A structPlan is built once per reflect.Type the first time an Engine validates a value of that type, and is then replayed for every subsequent value of that type.
+	Once built a structPlan is never modified, so it is safe to share between goroutines.
+*/
+type structPlan struct {
+	// fields contains the compiled instructions for each struct field that has validation tag data, in declaration order.
+	fields []fieldPlan
+	// self records whether the struct type implements SelfValidator or SelfReporter.
+	self selfValidation
+}
+
+// fieldPlan is the compiled validation instructions for a single struct field.
+type fieldPlan struct {
+	// index is the index of the field in the struct, used with reflect.Value.Field.
+	index int
+	// name is the name of the field as it appears in validation errors.
+	name string
+	// goName is the name of the field in the Go struct.
+	goName string
+	// validatorName is the validator name in the tag data, without the square bracket pairs.
+	validatorName string
+	// options contains the tag items read by the validator, after the items read by the validation package have been removed.
+	options []string
+	// arrayDepth is the number of square bracket pairs in front of the validator name in the tag data.
+	arrayDepth uint8
+	// required is true when the tag data contains the required option, see presenceOptions.
+	required bool
+	// omitEmpty is true when the tag data contains the omitempty option, see presenceOptions.
+	omitEmpty bool
+	// nullable is true when the tag data contains the nullable option, see presenceOptions.
+	nullable bool
+	// fieldValidator is the validator built from the tag data. It is nil when the validator name is "struct".
+	fieldValidator validator.Validator
+	// err is populated when the tag data could not be compiled, either because the validator is not registered or because its options are invalid.
+	err error
+	// messages contains the custom messages declared for the field.
+	messages fieldMessages
+	// collection contains the rules evaluated on a slice, array or map before its elements are validated.
+	collection collectionRules
+	// crossFields contains the rules comparing the field with other fields.
+	crossFields []crossFieldRule
+	// conditions contains the rules requiring the field, or requiring it to be empty, depending on other fields.
+	conditions []conditionalRule
+	// groups contains the validation groups the field is assigned to in its tag data.
+	groups []string
+	// ruleGroups contains the validation groups named by the rules of the field.
+	ruleGroups []string
+	// isMap is true when the field has map tag data, see isMapTag for more information.
+	isMap bool
+	// mapKeys is the compiled tag data for the keys of a map. It is nil when the map tag data has no keys section.
+	mapKeys *fieldPlan
+	// mapValues is the compiled tag data for the values of a map. It is nil when the map tag data has no values section.
+	mapValues *fieldPlan
+}
+
+// planKey is the key of a structPlan in the plan cache. Each combination of validation groups has its own plan, because the groups decide which fields and rules are compiled.
+type planKey struct {
+	structType reflect.Type
+	groups     string
+}
+
+// getStructPlan returns the cached structPlan for the provided struct type and validation groups, compiling and caching it first if needed.
+func (e *Engine) getStructPlan(structType reflect.Type, groups groupSet) *structPlan {
+	planCache := e.planCache.Load().(*sync.Map)
+	key := planKey{structType: structType, groups: groups.key()}
+	if plan, ok := planCache.Load(key); ok {
+		return plan.(*structPlan)
+	}
+	// If two goroutines compile the same type at the same time, the first plan stored wins and both use it.
+	plan, _ := planCache.LoadOrStore(key, e.compileStructPlan(structType, groups))
+	return plan.(*structPlan)
+}
+
+// resetPlanCache replaces the plan cache with an empty one, so plans are rebuilt the next time they are needed.
+func (e *Engine) resetPlanCache() {
+	e.planCache.Store(&sync.Map{})
+}
+
+// compileStructPlan reads the validation tag data for each field of the provided struct type and builds its structPlan, leaving out the fields and rules that are not in the validation groups.
+func (e *Engine) compileStructPlan(structType reflect.Type, groups groupSet) *structPlan {
+	plan := &structPlan{
+		self: newSelfValidation(structType),
+	}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag := field.Tag.Get(e.tagKey)
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name := e.fieldName(field)
+		messageTag := field.Tag.Get(e.tagKey + messageTagSuffix)
+		byCode, messageErr := parseMessageTag(messageTag)
+		var fieldPlan fieldPlan
+		if isMapTag(tag) {
+			fieldPlan = e.compileMapTag(name, tag, byCode, groups)
+		} else {
+			fieldPlan = e.compileTag(name, tag, 1, byCode, groups)
+		}
+		if messageErr != nil && fieldPlan.err == nil {
+			fieldPlan.err = newTagSyntaxError("", name, messageTag, messageErr)
+		}
+		fieldGroups := append(parseGroupsTag(field.Tag.Get(GroupsTagKey)), fieldPlan.groups...)
+		if !groups.includesField(fieldGroups, fieldPlan.ruleGroups) {
+			continue
+		}
+		fieldPlan.index = i
+		fieldPlan.name = name
+		fieldPlan.goName = field.Name
+		// cross field and conditional rules referencing a field that does not exist are reported once as a tag error and are not evaluated.
+		validCrossFields := make([]crossFieldRule, 0, len(fieldPlan.crossFields))
+		for _, rule := range fieldPlan.crossFields {
+			if err := rule.other.checkStructType(structType); err != nil {
+				if fieldPlan.err == nil {
+					fieldPlan.err = validator.NewFieldError("", validator.CodeBadTag, name, tag, nil, "").WithDetail("error", err.Error())
+				}
+				continue
+			}
+			validCrossFields = append(validCrossFields, rule)
+		}
+		fieldPlan.crossFields = validCrossFields
+		validConditions := make([]conditionalRule, 0, len(fieldPlan.conditions))
+		for _, rule := range fieldPlan.conditions {
+			if err := rule.checkStructType(structType); err != nil {
+				if fieldPlan.err == nil {
+					fieldPlan.err = validator.NewFieldError("", validator.CodeBadTag, name, tag, nil, "").WithDetail("error", err.Error())
+				}
+				continue
+			}
+			validConditions = append(validConditions, rule)
+		}
+		fieldPlan.conditions = validConditions
+		plan.fields = append(plan.fields, fieldPlan)
+	}
+	return plan
+}
+
+// compileTag builds the validator and parameters of a fieldPlan from the tag data of a field. The index and name of the fieldPlan are left for the caller to set.
+// The column is the position of the tag data in the full tag of the field, so syntax errors in a section of a map tag report the column in the full tag.
+func (e *Engine) compileTag(name, tag string, column int, byCode map[string]string, groups groupSet) fieldPlan {
+	tagArgs, syntaxErr := splitTagItems(tag, column, ',')
+	if syntaxErr != nil {
+		return fieldPlan{
+			err:      newTagSyntaxError("", name, tag, syntaxErr),
+			messages: fieldMessages{byCode: byCode},
+		}
+	}
+	validatorName, arrayDepth := getValidatorInfo(tagArgs[0])
+	// the message is extracted first, so an @ in the message is not read as a validation group.
+	items, message := extractMessageOption(tagArgs[1:])
+	items, fieldGroups, ruleGroups := groups.filterGroupItems(items)
+	var collection collectionRules
+	var collectionErr error
+	if arrayDepth > 0 {
+		// without [] pairs the value is not a collection, so the collection rule keys are left for the validator to read as its own options.
+		items, collection, collectionErr = extractCollectionRules(items)
+	}
+	items, crossFields, crossFieldErr := extractCrossFieldRules(items)
+	items, conditions, conditionErr := extractConditionalRules(items)
+	items, presence, presenceErr := extractPresenceOptions(items)
+	fieldValidator, err := e.getValidatorFromTag(validatorName, name)
+	for _, ruleErr := range []error{collectionErr, crossFieldErr, conditionErr, presenceErr} {
+		if err == nil && ruleErr != nil {
+			err = validator.NewFieldError(validatorName, validator.CodeBadTag, name, tag, nil, "").WithDetail("error", ruleErr.Error())
+		}
+	}
+	if err == nil && fieldValidator != nil {
+		if optionsErr := fieldValidator.ReadOptionsFromTagItems(items); optionsErr != nil {
+			err = validator.NewFieldError(validatorName, validator.CodeBadTag, name, tag, nil, "").WithDetail("error", optionsErr.Error())
+		}
+	}
+	if err == nil && fieldValidator != nil && e.strictOptions {
+		if optionErrs := checkOptions(fieldValidator, validatorName, items); len(optionErrs) > 0 {
+			err = validator.NewFieldError(validatorName, validator.CodeBadTag, name, tag, nil, "").WithDetail("error", optionErrs[0].Error())
+		}
+	}
+	return fieldPlan{
+		validatorName:  validatorName,
+		options:        items,
+		arrayDepth:     arrayDepth,
+		required:       presence.required,
+		omitEmpty:      presence.omitEmpty,
+		nullable:       presence.nullable,
+		fieldValidator: fieldValidator,
+		err:            err,
+		collection:     collection,
+		crossFields:    crossFields,
+		conditions:     conditions,
+		groups:         fieldGroups,
+		ruleGroups:     ruleGroups,
+		messages: fieldMessages{
+			all:    message,
+			byCode: byCode,
+		},
+	}
+}
+
+/*
+	splitTagItems splits tag data at each separator that is not quoted, escaped or in a list, and checks that every item can be read with validator.ParseOption.
+
+	The column is the position of the tag data in the full tag of the field, and is added to the column of a *validator.TagSyntaxError so it points into the full tag.
+*/
+func splitTagItems(tag string, column int, separator rune) ([]string, error) {
+	tagItems, err := validator.SplitTagItems(tag, separator)
+	if err != nil {
+		return nil, offsetTagSyntaxError(err, column-1)
+	}
+	items := make([]string, 0, len(tagItems))
+	for _, tagItem := range tagItems {
+		if _, err := validator.ParseOption(tagItem.Text); err != nil {
+			return nil, offsetTagSyntaxError(err, column+tagItem.Column-2)
+		}
+		items = append(items, tagItem.Text)
+	}
+	return items, nil
+}
+
+// offsetTagSyntaxError moves the column of a *validator.TagSyntaxError by the offset.
+func offsetTagSyntaxError(err error, offset int) error {
+	syntaxErr := &validator.TagSyntaxError{}
+	if errors.As(err, &syntaxErr) {
+		return &validator.TagSyntaxError{
+			Field:   syntaxErr.Field,
+			Column:  syntaxErr.Column + offset,
+			Message: syntaxErr.Message,
+		}
+	}
+	return err
+}
+
+// newTagSyntaxError creates the bad tag error for a syntax error in the tag data of a field, with the column of the syntax error as a detail.
+func newTagSyntaxError(validatorName, name, tag string, err error) error {
+	fieldError := validator.NewFieldError(validatorName, validator.CodeBadTag, name, tag, nil, "").WithDetail("error", err.Error())
+	syntaxErr := &validator.TagSyntaxError{}
+	if errors.As(err, &syntaxErr) {
+		fieldError = fieldError.WithDetail("column", strconv.Itoa(syntaxErr.Column))
+	}
+	return fieldError
+}
+
+// compileErrors returns the errors from compiling the tag data of the field, including the tag data for the keys and values of a map.
+func (fp fieldPlan) compileErrors() []error {
+	errs := []error{}
+	for _, plan := range []*fieldPlan{&fp, fp.mapKeys, fp.mapValues} {
+		if plan != nil && plan.err != nil {
+			errs = append(errs, plan.err)
+		}
+	}
+	return errs
+}
+
+// fieldPath returns the name used for a field in validation errors, which is the path to the field from the top level struct.
+func (fp fieldPlan) fieldPath(parentName string, structDepth uint8) string {
+	if structDepth > 1 {
+		// this is specifically for structs within structs to create a better reference to the name of the field being validated.
+		return fmt.Sprintf("%s.%s", parentName, fp.name)
+	}
+	return fp.name
+}
@@ -0,0 +1,139 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+func TestStructPlanIsCached(t *testing.T) {
+	e := New()
+	structType := reflect.TypeOf(TestStruct{})
+	firstPlan := e.getStructPlan(structType, defaultGroups)
+	secondPlan := e.getStructPlan(structType, defaultGroups)
+	if firstPlan != secondPlan {
+		t.Error("getStructPlan should return the same plan for the same type")
+	}
+	// Other, Other2 and Other3 have no validation tag data so they should not be in the plan.
+	simpleItemPlan := e.getStructPlan(reflect.TypeOf(SimpleItem{}), defaultGroups)
+	if len(simpleItemPlan.fields) != 2 {
+		t.Errorf("SimpleItem plan should have 2 fields but has %d", len(simpleItemPlan.fields))
+	}
+}
+
+func TestStructPlanRecordsTagErrors(t *testing.T) {
+	testValue := struct {
+		Name string `validate:"notarealvalidator"`
+		Age  int    `validate:"int,min=abc"`
+	}{}
+	plan := New().compileStructPlan(reflect.TypeOf(testValue), defaultGroups)
+	if plan.fields[0].err == nil || plan.fields[0].fieldValidator != nil {
+		t.Error("Name should have a compile error and no validator")
+	}
+	if plan.fields[1].err == nil || plan.fields[1].fieldValidator == nil {
+		t.Error("Age should have a compile error and still have a validator")
+	}
+}
+
+func TestStructPlanQuotedTagValues(t *testing.T) {
+	testValue := struct {
+		Name    string `validate:"string,min=5,msg='Please, enter a longer name'"`
+		City    string `validate:"string,required_if=Country 'New Zealand'"`
+		Country string `validate:"string"`
+	}{Name: "abc", Country: "New Zealand"}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	if errs := validationError.Errors["Name"]; len(errs) != 1 || errs[0].Error() != "Please, enter a longer name" {
+		t.Errorf("Name should have the quoted message: %v", errs)
+	}
+	if errs := validationError.Errors["City"]; len(errs) != 1 || !errors.Is(errs[0], validator.ErrRequired) {
+		t.Errorf("City should be required when Country is New Zealand: %v", errs)
+	}
+}
+
+func TestStructPlanTagSyntaxErrors(t *testing.T) {
+	testValue := struct {
+		Name  string         `validate:"string,msg='unterminated"`
+		Codes map[string]int `validate:"keys=string;values=int,msg='a"`
+	}{}
+	plan := New().compileStructPlan(reflect.TypeOf(testValue), defaultGroups)
+	for i, expectedColumn := range []string{"12", "28"} {
+		fieldError, ok := plan.fields[i].compileErrors()[0].(*validator.FieldError)
+		if !ok {
+			t.Fatalf("%s should have a *validator.FieldError compile error", plan.fields[i].name)
+		}
+		if fieldError.Code != validator.CodeBadTag || fieldError.Details["column"] != expectedColumn {
+			t.Errorf("%s should have a bad tag error at column %s: %+v", plan.fields[i].name, expectedColumn, fieldError)
+		}
+	}
+}
+
+func TestConcurrentValidation(t *testing.T) {
+	e := New()
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(age int) {
+			defer wg.Done()
+			testStruct := newBenchmarkStruct()
+			testStruct.Age = age
+			validationError := e.ValidateStructWithTag(testStruct)
+			if age > 150 && validationError == nil {
+				t.Errorf("age %d should have failed validation", age)
+			} else if age <= 150 && validationError != nil {
+				t.Errorf("age %d should not have failed validation: %s", age, validationError.Error())
+			}
+		}(i * 20)
+	}
+	wg.Wait()
+}
+
+func newBenchmarkStruct() TestStruct {
+	var score uint16 = 7
+	var arryData = []int{1, 2, 3, 4, 5}
+	return TestStruct{
+		Age:        33,
+		Arry:       &arryData,
+		Email:      "test@user.com",
+		Name:       "Calvin",
+		PostalCode: "32105",
+		Score:      &score,
+		Detail: Details{
+			Name:  "detail",
+			Value: 12,
+		},
+		OtherThing: OtherThing{
+			ID:          11,
+			Description: "this is a long enough description",
+		},
+		TheTime: time.Now(),
+	}
+}
+
+func BenchmarkValidateStructWithTag(b *testing.B) {
+	testStruct := newBenchmarkStruct()
+	e := New()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.ValidateStructWithTag(testStruct)
+	}
+}
+
+// BenchmarkValidateStructWithTagUncached clears the plan cache before each validation to measure the cost of re-parsing the tag data on every call.
+func BenchmarkValidateStructWithTagUncached(b *testing.B) {
+	testStruct := newBenchmarkStruct()
+	e := New()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.resetPlanCache()
+		e.ValidateStructWithTag(testStruct)
+	}
+}
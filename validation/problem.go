@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+const (
+	// ProblemContentType is the media type of an RFC 7807 problem details document.
+	ProblemContentType = "application/problem+json"
+	// ProblemType is the problem type used by Problem. "about:blank" means the problem has no semantics beyond the HTTP status code.
+	ProblemType = "about:blank"
+	// ProblemTitle is the title used by Problem.
+	ProblemTitle = "Unprocessable Entity"
+
+	problemDetailTemplate = "%s validation failed"
+)
+
+// fieldErrorJSON is the JSON representation of a single error in a ValidationError.
+type fieldErrorJSON struct {
+	Validator string `json:"validator,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Param     string `json:"param,omitempty"`
+	Message   string `json:"message"`
+}
+
+// validationErrorJSON is the JSON representation of a ValidationError.
+type validationErrorJSON struct {
+	DataType  string                      `json:"dataType,omitempty"`
+	Errors    map[string][]fieldErrorJSON `json:"errors"`
+	Truncated bool                        `json:"truncated,omitempty"`
+}
+
+/*
+	Problem is an RFC 7807 problem details document describing a ValidationError.
+
+	Each field that failed validation has an entry in InvalidParams for each of its errors.
+*/
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params"`
+	// Truncated is true when validation stopped before the whole value was validated, so there may be more invalid params.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// InvalidParam describes a single validation failure in a Problem.
+type InvalidParam struct {
+	// Name is the name of the field that failed validation.
+	Name string `json:"name"`
+	// Reason is the error message for the failure.
+	Reason string `json:"reason"`
+	// Code is the rule code for the failure when it is known, for instance "min".
+	Code string `json:"code,omitempty"`
+}
+
+// newFieldErrorJSON converts an error into its JSON representation, including the structured information when it is a *validator.FieldError.
+func newFieldErrorJSON(err error) fieldErrorJSON {
+	errJSON := fieldErrorJSON{
+		Message: err.Error(),
+	}
+	var fieldError *validator.FieldError
+	if errors.As(err, &fieldError) {
+		errJSON.Validator = fieldError.Validator
+		errJSON.Code = fieldError.Code
+		errJSON.Param = fieldError.Param
+	}
+	return errJSON
+}
+
+/*
+	MarshalJSON implements json.Marshaler for ValidationError. The document has the format:
+
+		{
+			"dataType": "TestStruct",
+			"errors": {
+				"Age": [{ "validator": "int", "code": "max", "param": "150", "message": "max: ..." }]
+			}
+		}
+
+	The document also has "truncated": true when validation stopped before the whole value was validated.
+	The values that failed validation are not included, since they may contain sensitive data.
+*/
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	document := validationErrorJSON{
+		DataType:  e.DataType,
+		Errors:    make(map[string][]fieldErrorJSON, len(e.Errors)),
+		Truncated: e.Truncated,
+	}
+	for key, errs := range e.Errors {
+		errsJSON := make([]fieldErrorJSON, 0, len(errs))
+		for _, err := range errs {
+			errsJSON = append(errsJSON, newFieldErrorJSON(err))
+		}
+		document.Errors[key] = errsJSON
+	}
+	return json.Marshal(document)
+}
+
+// Problem converts the ValidationError into an RFC 7807 problem details document with the status 422 Unprocessable Entity.
+func (e *ValidationError) Problem() *Problem {
+	return e.ProblemLocale(validator.DefaultLocale)
+}
+
+// ProblemLocale converts the ValidationError into an RFC 7807 problem details document, with each reason rendered with the message catalog for the locale, see WithCatalog.
+func (e *ValidationError) ProblemLocale(locale string) *Problem {
+	dataType := e.DataType
+	if dataType == "" {
+		dataType = "value"
+	}
+	catalog := e.catalog(locale)
+	problem := &Problem{
+		Type:          ProblemType,
+		Title:         ProblemTitle,
+		Status:        http.StatusUnprocessableEntity,
+		Detail:        fmt.Sprintf(problemDetailTemplate, dataType),
+		InvalidParams: []InvalidParam{},
+		Truncated:     e.Truncated,
+	}
+	for _, key := range e.orderedKeys() {
+		for _, err := range e.Errors[key] {
+			errJSON := newFieldErrorJSON(err)
+			problem.InvalidParams = append(problem.InvalidParams, InvalidParam{
+				Name:   key,
+				Reason: validator.LocalizeCatalogError(err, catalog),
+				Code:   errJSON.Code,
+			})
+		}
+	}
+	return problem
+}
+
+// WriteProblem writes the ValidationError to the http.ResponseWriter as an application/problem+json response with the status 422 Unprocessable Entity.
+func WriteProblem(w http.ResponseWriter, validationError *ValidationError) error {
+	body, err := json.Marshal(validationError.Problem())
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type ProblemItem struct {
+	Name string `validate:"string,min=5"`
+	Age  int    `validate:"int,max=150"`
+}
+
+func TestValidationErrorMarshalJSON(t *testing.T) {
+	validationError := ValidateStructWithTag(ProblemItem{Name: "abc", Age: 200})
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	first, err := json.Marshal(validationError)
+	if err != nil {
+		t.Fatal("marshaling validationError should not fail", err)
+	}
+	second, _ := json.Marshal(validationError)
+	if string(first) != string(second) {
+		t.Error("marshaling validationError should produce the same document every time")
+	}
+	var document validationErrorJSON
+	if err := json.Unmarshal(first, &document); err != nil {
+		t.Fatal("the document should be valid JSON", err)
+	}
+	expected := map[string][]fieldErrorJSON{
+		"Name": {newFieldErrorJSON(validationError.Errors["Name"][0])},
+		"Age":  {newFieldErrorJSON(validationError.Errors["Age"][0])},
+	}
+	if !reflect.DeepEqual(document.Errors, expected) {
+		t.Errorf("document errors should be %v but were %v", expected, document.Errors)
+	}
+	if document.Errors["Age"][0].Code != "max" || document.Errors["Age"][0].Param != "150" {
+		t.Errorf("the Age error should have the max code and the param 150: %+v", document.Errors["Age"][0])
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	validationError := ValidateStructWithTag(ProblemItem{Name: "abc", Age: 200})
+	recorder := httptest.NewRecorder()
+	if err := WriteProblem(recorder, validationError); err != nil {
+		t.Fatal("WriteProblem should not fail", err)
+	}
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Errorf("the status should be %d but was %d", http.StatusUnprocessableEntity, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != ProblemContentType {
+		t.Errorf("the content type should be %s but was %s", ProblemContentType, contentType)
+	}
+	var problem Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatal("the body should be valid JSON", err)
+	}
+	if problem.Status != http.StatusUnprocessableEntity || problem.Type != ProblemType || len(problem.InvalidParams) != 2 {
+		t.Errorf("the problem does not have the expected values: %+v", problem)
+	}
+	if problem.InvalidParams[0].Name != "Name" || problem.InvalidParams[0].Code != "min" {
+		t.Errorf("the first invalid param should be the Name min error: %+v", problem.InvalidParams[0])
+	}
+}
@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// rootPathPrefix starts a field reference that is a path from the top level struct instead of the struct containing the field.
+	rootPathPrefix = "$."
+
+	fieldReferenceMissingErrorTemplate = "the field %s does not exist"
+)
+
+/*
+	fieldReference refers to another field from the tag data of a field, for the cross field and conditional requirement rules.
+
+	The other field is named by its Go field name relative to the struct containing the field, and nested fields are named with a dotted path like "Limits.MaxGuests".
+	When the name starts with "$." the path starts at the top level struct instead.
+*/
+type fieldReference struct {
+	// name is the reference as it appears in the tag data.
+	name string
+	// path contains the names of the fields to follow to reach the other field.
+	path []string
+	// fromRoot is true when the path starts at the top level struct.
+	fromRoot bool
+}
+
+// parseFieldReference parses a reference to another field from the tag data.
+func parseFieldReference(name string) fieldReference {
+	reference := fieldReference{
+		name: name,
+	}
+	path := name
+	if strings.HasPrefix(path, rootPathPrefix) {
+		reference.fromRoot = true
+		path = strings.TrimPrefix(path, rootPathPrefix)
+	}
+	reference.path = strings.Split(path, ".")
+	return reference
+}
+
+// checkStructType returns an error when the reference names a field that does not exist in the struct type containing the field. Paths from the top level struct are checked when they are resolved.
+func (fr fieldReference) checkStructType(structType reflect.Type) error {
+	if fr.fromRoot {
+		return nil
+	}
+	currentType := structType
+	for _, name := range fr.path {
+		for currentType.Kind() == reflect.Ptr {
+			currentType = currentType.Elem()
+		}
+		if currentType.Kind() != reflect.Struct {
+			return fmt.Errorf(fieldReferenceMissingErrorTemplate, fr.name)
+		}
+		field, ok := currentType.FieldByName(name)
+		if !ok {
+			return fmt.Errorf(fieldReferenceMissingErrorTemplate, fr.name)
+		}
+		currentType = field.Type
+	}
+	return nil
+}
+
+// resolve returns the value of the referenced field, starting from the struct containing the field or from the top level struct.
+// It returns false when a nil pointer is found along the path, including a nil embedded struct pointer that a promoted field is read through, and an error when a field in the path does not exist.
+func (fr fieldReference) resolve(parent, root reflect.Value) (reflect.Value, bool, error) {
+	current := parent
+	if fr.fromRoot {
+		current = root
+	}
+	for _, name := range fr.path {
+		var ok bool
+		if current, ok = derefValue(current); !ok {
+			return current, false, nil
+		}
+		if current.Kind() != reflect.Struct {
+			return current, false, fmt.Errorf(fieldReferenceMissingErrorTemplate, fr.name)
+		}
+		field, found := current.Type().FieldByName(name)
+		if !found {
+			return current, false, fmt.Errorf(fieldReferenceMissingErrorTemplate, fr.name)
+		}
+		fieldValue, err := current.FieldByIndexErr(field.Index)
+		if err != nil {
+			// the field is promoted through an embedded struct pointer that is nil, so like any other nil pointer it cannot be resolved.
+			return current, false, nil
+		}
+		if current = fieldValue; !current.CanInterface() {
+			return current, false, fmt.Errorf(fieldReferenceMissingErrorTemplate, fr.name)
+		}
+	}
+	current, ok := derefValue(current)
+	return current, ok, nil
+}
+
+// derefValue dereferences pointers and interfaces until it reaches a value. It returns false when it reaches a nil pointer or interface.
+func derefValue(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+	return value, value.IsValid()
+}
@@ -0,0 +1,162 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+/*
+	DuplicatePolicy determines what happens when a validator or alias is registered with a name that is already registered.
+*/
+type DuplicatePolicy uint8
+
+const (
+	// DuplicateOverride replaces the existing validator or alias with the new one. This is the default policy.
+	DuplicateOverride DuplicatePolicy = iota
+	// DuplicateError leaves the existing validator or alias in place and returns an error wrapping ErrDuplicateValidator.
+	DuplicateError
+)
+
+const (
+	// structValidatorName is the reserved validator name used in tag data to validate the fields of a struct value.
+	structValidatorName = "struct"
+
+	duplicateValidatorErrorTemplate = "%s is already registered"
+	reservedValidatorErrorTemplate  = "%s is reserved and cannot be registered"
+	aliasTargetMissingErrorTemplate = "cannot alias %s to %s because %s is not registered"
+)
+
+var (
+	// ErrDuplicateValidator is returned when registering a validator or alias under a name that is already registered and the DuplicatePolicy is DuplicateError.
+	ErrDuplicateValidator = errors.New("duplicate validator")
+	// ErrInvalidRegistration is returned when a validator or alias registration is not valid, for instance when the name is reserved or an alias refers to a validator that is not registered.
+	ErrInvalidRegistration = errors.New("invalid registration")
+)
+
+/*
+	registry holds the validator factories and aliases registered with an Engine.
+
+	The registry is safe for concurrent use, so validators may be registered or unregistered while other goroutines are validating values.
+*/
+type registry struct {
+	mu sync.RWMutex
+	// factories contains a validator.ValidatorFactory for each registered validator name.
+	factories map[string]validator.ValidatorFactory
+	// aliases maps an alias to the validator name it refers to.
+	aliases map[string]string
+	// policy determines what happens when a name is registered twice.
+	policy DuplicatePolicy
+}
+
+// isReservedName returns true for the validator names with a special meaning in tag data, which cannot be registered.
+func isReservedName(name string) bool {
+	return name == structValidatorName || name == mapValidatorName
+}
+
+// newRegistry creates a registry containing the provided validator factories.
+func newRegistry(factories map[string]validator.ValidatorFactory) *registry {
+	return &registry{
+		factories: factories,
+		aliases:   map[string]string{},
+	}
+}
+
+// lookup returns the validator factory registered for the provided name or alias.
+func (r *registry) lookup(name string) (validator.ValidatorFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if target, ok := r.aliases[name]; ok {
+		name = target
+	}
+	factory, ok := r.factories[name]
+	return factory, ok
+}
+
+// isRegistered returns true if the name is registered as either a validator or an alias. The caller must hold the lock.
+func (r *registry) isRegistered(name string) bool {
+	_, isValidator := r.factories[name]
+	_, isAlias := r.aliases[name]
+	return isValidator || isAlias
+}
+
+// register adds the validator factory under the provided name, applying the registry DuplicatePolicy when the name is already registered.
+func (r *registry) register(name string, factory validator.ValidatorFactory) error {
+	if isReservedName(name) {
+		return fmt.Errorf("%w: "+reservedValidatorErrorTemplate, ErrInvalidRegistration, name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.policy == DuplicateError && r.isRegistered(name) {
+		return fmt.Errorf("%w: "+duplicateValidatorErrorTemplate, ErrDuplicateValidator, name)
+	}
+	// a validator replaces any alias with the same name.
+	delete(r.aliases, name)
+	r.factories[name] = factory
+	return nil
+}
+
+// registerAlias makes alias refer to the validator registered under name, applying the registry DuplicatePolicy when the alias is already registered.
+func (r *registry) registerAlias(alias, name string) error {
+	if isReservedName(alias) {
+		return fmt.Errorf("%w: "+reservedValidatorErrorTemplate, ErrInvalidRegistration, alias)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.factories[name]; !ok {
+		return fmt.Errorf("%w: "+aliasTargetMissingErrorTemplate, ErrInvalidRegistration, alias, name, name)
+	}
+	if r.policy == DuplicateError && r.isRegistered(alias) {
+		return fmt.Errorf("%w: "+duplicateValidatorErrorTemplate, ErrDuplicateValidator, alias)
+	}
+	delete(r.factories, alias)
+	r.aliases[alias] = name
+	return nil
+}
+
+// unregister removes the validator or alias registered under the provided name.
+// When a validator is removed, every alias referring to it is removed too. It returns false if nothing was registered under the name.
+func (r *registry) unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.aliases[name]; ok {
+		delete(r.aliases, name)
+		return true
+	}
+	if _, ok := r.factories[name]; !ok {
+		return false
+	}
+	delete(r.factories, name)
+	for alias, target := range r.aliases {
+		if target == name {
+			delete(r.aliases, alias)
+		}
+	}
+	return true
+}
+
+// names returns the sorted names of the registered validators, not including aliases.
+func (r *registry) names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// aliasMap returns a copy of the registered aliases, mapping each alias to the validator name it refers to.
+func (r *registry) aliasMap() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	aliases := make(map[string]string, len(r.aliases))
+	for alias, name := range r.aliases {
+		aliases[alias] = name
+	}
+	return aliases
+}
@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRegisteredValidators(t *testing.T) {
+	expected := []string{"email", "float", "int", "postalcode", "string", "time", "uint", "uuid"}
+	registered := New().RegisteredValidators()
+	if !reflect.DeepEqual(registered, expected) {
+		t.Errorf("RegisteredValidators should be %v but was %v", expected, registered)
+	}
+}
+
+func TestUnregisterValidator(t *testing.T) {
+	e := New()
+	if !e.UnregisterValidator("email") {
+		t.Error("UnregisterValidator should return true when the validator is registered")
+	}
+	if e.UnregisterValidator("email") {
+		t.Error("UnregisterValidator should return false when the validator is not registered")
+	}
+	testValue := struct {
+		Email string `validate:"email"`
+	}{
+		Email: "test@user.com",
+	}
+	if validationError := e.ValidateStructWithTag(testValue); validationError == nil {
+		t.Error("email is no longer registered so validation should fail")
+	}
+}
+
+func TestDuplicatePolicyError(t *testing.T) {
+	e := New(WithDuplicatePolicy(DuplicateError))
+	err := e.RegisterValidator("email", newPhoneValidator)
+	if !errors.Is(err, ErrDuplicateValidator) {
+		t.Error("registering email twice should return ErrDuplicateValidator", err)
+	}
+	if err = e.RegisterValidator("phone", newPhoneValidator); err != nil {
+		t.Error("registering phone should succeed", err)
+	}
+	if err = e.RegisterAlias("string", "phone"); !errors.Is(err, ErrDuplicateValidator) {
+		t.Error("aliasing over a registered validator should return ErrDuplicateValidator", err)
+	}
+}
+
+func TestDuplicatePolicyOverride(t *testing.T) {
+	e := New()
+	if err := e.RegisterValidator("string", newPhoneValidator); err != nil {
+		t.Error("overriding string should succeed", err)
+	}
+	testValue := struct {
+		Phone string `validate:"string"`
+	}{
+		Phone: "123",
+	}
+	if validationError := e.ValidateStructWithTag(testValue); validationError == nil {
+		t.Error("string should have been replaced by the phone validator")
+	}
+}
+
+func TestReservedValidatorName(t *testing.T) {
+	e := New()
+	if err := e.RegisterValidator("struct", newPhoneValidator); !errors.Is(err, ErrInvalidRegistration) {
+		t.Error("registering struct should return ErrInvalidRegistration", err)
+	}
+	if err := e.RegisterAlias("struct", "string"); !errors.Is(err, ErrInvalidRegistration) {
+		t.Error("aliasing struct should return ErrInvalidRegistration", err)
+	}
+}
+
+func TestRegisterAlias(t *testing.T) {
+	e := New()
+	if err := e.RegisterAlias("zip", "notregistered"); !errors.Is(err, ErrInvalidRegistration) {
+		t.Error("aliasing a validator that is not registered should return ErrInvalidRegistration", err)
+	}
+	if err := e.RegisterAlias("zip", "postalcode"); err != nil {
+		t.Error("aliasing postalcode should succeed", err)
+	}
+	testValue := struct {
+		Zip string `validate:"zip"`
+	}{
+		Zip: "abc",
+	}
+	validationError := e.ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Error("zip should validate with the postalcode validator")
+	} else if _, ok := validationError.Errors["Zip"]; !ok {
+		t.Error("validationError.Errors should contain key 'Zip'", validationError.Error())
+	}
+	if aliases := e.RegisteredAliases(); aliases["zip"] != "postalcode" {
+		t.Error("RegisteredAliases should map zip to postalcode", aliases)
+	}
+	e.UnregisterValidator("postalcode")
+	if aliases := e.RegisteredAliases(); len(aliases) != 0 {
+		t.Error("unregistering postalcode should remove the zip alias", aliases)
+	}
+}
+
+func TestConcurrentRegistration(t *testing.T) {
+	e := New()
+	testValue := PhoneItem{Phone: "1234567890"}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			e.RegisterValidator("phone", newPhoneValidator)
+			e.UnregisterValidator("phone")
+		}()
+		go func() {
+			defer wg.Done()
+			e.ValidateStructWithTag(testValue)
+		}()
+	}
+	wg.Wait()
+}
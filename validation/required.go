@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+const (
+	// requiredOption is the tag option that makes a field required.
+	requiredOption = "required"
+	// omitEmptyOption is the tag option that skips every rule of a field when it is empty.
+	omitEmptyOption = "omitempty"
+	// nullableOption is the tag option that allows a pointer field to be nil even when it is required.
+	nullableOption = "nullable"
+
+	requiredOmitEmptyErrorTemplate = "required cannot be combined with omitempty"
+)
+
+/*
+	presenceOptions are the tag options deciding what happens when a field is empty, see isEmptyValue.
+
+	They can be anywhere in the tag data and work the same for every validator, since they are checked by the validation package before the validator is called:
+
+		`validate:"int,required,min=18"`            the field must be provided and must be at least 18
+		`validate:"int,omitempty,min=18"`           the field may be empty, and when it is provided it must be at least 18
+		`validate:"string,required,nullable,min=3"` the pointer field may be nil, and when it is not nil it must point to a string of at least 3 characters
+
+	A field with none of them is validated even when it is empty, except for a nil pointer which is never validated unless the field is required.
+	An item like required=false is not one of the options, it is left for the validator, and with WithStrictOptions it is a bad tag error.
+*/
+type presenceOptions struct {
+	// required is true when an empty field is an error.
+	required bool
+	// omitEmpty is true when an empty field is not validated.
+	omitEmpty bool
+	// nullable is true when a nil pointer is valid even when the field is required.
+	nullable bool
+}
+
+// extractPresenceOptions removes the presence options from the tag items, returning the remaining items for the validator and the options that were found.
+func extractPresenceOptions(items []string) ([]string, presenceOptions, error) {
+	options := presenceOptions{}
+	validatorItems := make([]string, 0, len(items))
+	for _, item := range items {
+		// the items were checked when they were split, so the option can always be read.
+		option, _ := validator.ParseOption(item)
+		if option.HasValue {
+			validatorItems = append(validatorItems, item)
+			continue
+		}
+		switch option.Key {
+		case requiredOption:
+			options.required = true
+		case omitEmptyOption:
+			options.omitEmpty = true
+		case nullableOption:
+			options.nullable = true
+		default:
+			validatorItems = append(validatorItems, item)
+		}
+	}
+	if options.required && options.omitEmpty {
+		return validatorItems, options, errors.New(requiredOmitEmptyErrorTemplate)
+	}
+	return validatorItems, options, nil
+}
+
+/*
+	isEmptyValue returns true when the value is the zero value for its type, for instance 0, false, "", a nil pointer or a struct with every field at its zero value.
+	Slices and maps are also empty when they have no elements, and an invalid value, from a nil interface, is empty.
+*/
+func isEmptyValue(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+// isNullValue returns true when the value is a nil pointer, or an invalid value from a nil interface.
+func isNullValue(value reflect.Value) bool {
+	return !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil())
+}
@@ -0,0 +1,191 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/calvine/simplevalidation/validation/validationparams"
+	"github.com/calvine/simplevalidation/validator"
+	"github.com/calvine/simplevalidation/validator/stringvalidator"
+)
+
+type RequiredDetail struct {
+	Name string `validate:"string"`
+}
+
+type RequiredItem struct {
+	Count    int               `validate:"int,min=1,required"`
+	Price    float64           `validate:"float,required"`
+	Quantity uint              `validate:"uint,required"`
+	Created  time.Time         `validate:"time,required"`
+	Detail   RequiredDetail    `validate:"struct,required"`
+	Tags     []string          `validate:"[]string,required"`
+	Labels   map[string]string `validate:"map,required;values=string"`
+	Note     *string           `validate:"string,required,msg=a note is required"`
+	Optional int               `validate:"int,required=false"`
+}
+
+func TestRequiredZeroValues(t *testing.T) {
+	validationError := ValidateStructWithTag(RequiredItem{Tags: []string{}})
+	if validationError == nil {
+		t.Fatal("every required field should have failed validation")
+	}
+	expected := []string{"Count", "Price", "Quantity", "Created", "Detail", "Tags", "Labels", "Note"}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("the error keys should be %v but were %v", expected, keys)
+	}
+	for _, key := range expected {
+		if errs := validationError.Errors[key]; len(errs) != 1 || !errors.Is(errs[0], validator.ErrRequired) {
+			t.Errorf("%s should have a single required error: %v", key, errs)
+		}
+	}
+	if message := validationError.Errors["Note"][0].Error(); message != "a note is required" {
+		t.Errorf("the required error for Note should have the custom message: %s", message)
+	}
+}
+
+func TestRequiredNonZeroValues(t *testing.T) {
+	note := ""
+	testValue := RequiredItem{
+		Count:    3,
+		Price:    1.5,
+		Quantity: 2,
+		Created:  time.Now(),
+		Detail:   RequiredDetail{Name: "detail"},
+		Tags:     []string{"a"},
+		Labels:   map[string]string{"a": "b"},
+		Note:     &note,
+	}
+	if validationError := ValidateStructWithTag(testValue); validationError != nil {
+		t.Errorf("every required field is provided, including Note which points to an empty string: %v", validationError)
+	}
+}
+
+type RequiredContainer struct {
+	Nums     []int `validate:"[]int,required,min=0"`
+	Count    *int  `validate:"int,required"`
+	Optional *int  `validate:"int,required,nullable"`
+}
+
+func TestRequiredElementsAndPointerTargets(t *testing.T) {
+	zero := 0
+	testValue := RequiredContainer{
+		Nums:     []int{0, 5},
+		Count:    &zero,
+		Optional: &zero,
+	}
+	if validationError := ValidateStructWithTag(testValue); validationError != nil {
+		t.Errorf("zero value elements and pointers to zero values should not fail required: %v", validationError)
+	}
+	validationError := ValidateStructWithTag(RequiredContainer{Nums: []int{}})
+	if validationError == nil {
+		t.Fatal("an empty slice and a nil pointer should fail required")
+	}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, []string{"Nums", "Count"}) {
+		t.Errorf("only Nums and Count should have failed validation: %v", validationError)
+	}
+}
+
+func TestRequiredValidationParams(t *testing.T) {
+	for _, value := range []interface{}{0, false, "", 0.0, []int{}} {
+		validationError, err := Validate(&validationparams.ValidationParams{
+			Name:     "value",
+			Required: true,
+			Value:    value,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if validationError == nil || !errors.Is(validationError, validator.ErrRequired) {
+			t.Errorf("%#v should fail validation when Required is true: %v", value, validationError)
+		}
+	}
+}
+
+type OptionalItem struct {
+	Age      int      `validate:"int,omitempty,min=18"`
+	Nickname string   `validate:"string,omitempty,min=3"`
+	Website  string   `validate:"string,omitempty,eqfield=Homepage"`
+	Homepage string   `validate:"string"`
+	Tags     []string `validate:"[]string,omitempty,minlen=2"`
+	Email    *string  `validate:"email,omitempty"`
+	Note     *string  `validate:"string,required,nullable,min=3"`
+	Comment  *string  `validate:"string,nullable,min=3"`
+}
+
+func TestOmitEmptyValues(t *testing.T) {
+	testValue := OptionalItem{
+		Homepage: "https://example.com",
+		Tags:     []string{},
+	}
+	if validationError := ValidateStructWithTag(testValue); validationError != nil {
+		t.Errorf("empty fields with omitempty and nil fields with nullable should not fail validation: %v", validationError)
+	}
+}
+
+func TestOmitEmptyProvidedValues(t *testing.T) {
+	email := "not an email"
+	testValue := OptionalItem{
+		Age:      5,
+		Nickname: "ab",
+		Website:  "https://example.org",
+		Homepage: "https://example.com",
+		Tags:     []string{"a"},
+		Email:    &email,
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("every provided field should have failed validation")
+	}
+	expected := []string{"Age", "Nickname", "Website", "Tags", "Email"}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("the error keys should be %v but were %v: %v", expected, keys, validationError)
+	}
+}
+
+func TestNullableNotNil(t *testing.T) {
+	empty, short := "", "ab"
+	validationError := ValidateStructWithTag(OptionalItem{Note: &empty, Comment: &short})
+	if validationError == nil {
+		t.Fatal("Note and Comment should have failed validation")
+	}
+	if errs := validationError.Errors["Note"]; len(errs) != 1 || !errors.Is(errs[0], validator.ErrMin) {
+		t.Errorf("Note is not nil so it should have a single min error: %v", errs)
+	}
+	if errs := validationError.Errors["Comment"]; len(errs) != 1 || !errors.Is(errs[0], validator.ErrMin) {
+		t.Errorf("Comment is not nil so it should have a single min error: %v", errs)
+	}
+}
+
+func TestRequiredOmitEmptyBadTag(t *testing.T) {
+	testValue := struct {
+		Age int `validate:"int,required,omitempty"`
+	}{Age: 20}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil || !errors.Is(validationError, validator.ErrBadTag) {
+		t.Errorf("required and omitempty together should be a bad tag error: %v", validationError)
+	}
+}
+
+func TestOmitEmptyValidationParams(t *testing.T) {
+	fieldValidator := stringvalidator.New()
+	if err := fieldValidator.ReadOptionsFromTagItems([]string{"min=1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, omitEmpty := range []bool{true, false} {
+		validationError, err := Validate(&validationparams.ValidationParams{
+			Name:           "value",
+			OmitEmpty:      omitEmpty,
+			FieldValidator: fieldValidator,
+			Value:          "",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if failed := validationError != nil; failed == omitEmpty {
+			t.Errorf("an empty string should only fail min=1 when OmitEmpty is false, OmitEmpty is %t: %v", omitEmpty, validationError)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+/*
+	SelfValidator is implemented by struct types with invariants that span many fields and do not fit into tag data.
+
+	ValidateSelf is called after the fields of the struct are validated, for the top level struct and for every nested struct reached with the "struct" validator name.
+	A non nil error is added to the ValidationError under the name of the struct, which is "value" for the top level struct.
+*/
+type SelfValidator interface {
+	ValidateSelf() error
+}
+
+/*
+	SelfReporter is implemented by struct types that validate themselves and report errors for their individual fields.
+
+	ReportSelf is called at the same point as SelfValidator.ValidateSelf, and reports its errors through the Reporter:
+
+		func (b Booking) ReportSelf(r *validation.Reporter) {
+			if b.Nights > 14 && b.Guests > 4 {
+				r.Report("Nights", errors.New("long stays are limited to 4 guests"))
+			}
+		}
+*/
+type SelfReporter interface {
+	ReportSelf(r *Reporter)
+}
+
+var (
+	selfValidatorType = reflect.TypeOf((*SelfValidator)(nil)).Elem()
+	selfReporterType  = reflect.TypeOf((*SelfReporter)(nil)).Elem()
+)
+
+// Reporter adds the errors reported by a SelfReporter to the ValidationError, naming each error after the field of the struct it is reported for.
+type Reporter struct {
+	// engine is the Engine validating the struct, whose NameFunc names the reported fields.
+	engine *Engine
+	// structType is the type of the struct, used to look up the reported fields.
+	structType reflect.Type
+	// structName is the name of the struct in validation errors.
+	structName string
+	// structDepth is the StructDepth of the fields of the struct.
+	structDepth uint8
+	// state is the state of the validation run the errors are added to.
+	state *validationState
+}
+
+/*
+	Report adds an error for the named field of the struct. The field is the Go field name, or a dotted path for a nested field. When the field is empty the error is added for the struct itself.
+
+	The Go field names are named with the NameFunc of the Engine, so the errors have the same names as the errors from the tag data of the fields.
+*/
+func (r *Reporter) Report(field string, err error) {
+	if err == nil {
+		return
+	}
+	name := r.structName
+	if field != "" {
+		name = fieldPlan{name: r.fieldPath(field)}.fieldPath(r.structName, r.structDepth)
+	}
+	r.state.addErrors(name, withFieldName(err, name))
+}
+
+// fieldPath names each Go field name in a dotted path like "Rooms[1].Beds" with the NameFunc of the Engine. The names that are not fields of the struct are left as they are.
+func (r *Reporter) fieldPath(field string) string {
+	currentType := r.structType
+	segments := strings.Split(field, ".")
+	for i, segment := range segments {
+		goName, index := segment, ""
+		if bracket := strings.IndexByte(segment, '['); bracket >= 0 {
+			goName, index = segment[:bracket], segment[bracket:]
+		}
+		currentType = derefType(currentType)
+		if currentType.Kind() != reflect.Struct {
+			break
+		}
+		structField, ok := currentType.FieldByName(goName)
+		if !ok {
+			break
+		}
+		segments[i] = r.engine.fieldName(structField) + index
+		currentType = structField.Type
+		// each index steps into the elements of a slice, array or map.
+		for j := strings.Count(index, "["); j > 0; j-- {
+			currentType = derefType(currentType)
+			switch currentType.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				currentType = currentType.Elem()
+			}
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+// selfValidation records how a struct type validates itself.
+type selfValidation struct {
+	// validator is true when the struct type implements SelfValidator.
+	validator bool
+	// reporter is true when the struct type implements SelfReporter.
+	reporter bool
+	// pointerReceiver is true when the interfaces are implemented by a pointer to the struct type instead of the struct type.
+	pointerReceiver bool
+}
+
+// newSelfValidation checks which of SelfValidator and SelfReporter the struct type implements.
+func newSelfValidation(structType reflect.Type) selfValidation {
+	// a pointer to the struct type implements every method of the struct type, along with the methods with a pointer receiver.
+	pointerType := reflect.PtrTo(structType)
+	sv := selfValidation{
+		validator: pointerType.Implements(selfValidatorType),
+		reporter:  pointerType.Implements(selfReporterType),
+	}
+	sv.pointerReceiver = (sv.validator && !structType.Implements(selfValidatorType)) || (sv.reporter && !structType.Implements(selfReporterType))
+	return sv
+}
+
+// implemented returns true when the struct type implements SelfValidator or SelfReporter.
+func (sv selfValidation) implemented() bool {
+	return sv.validator || sv.reporter
+}
+
+// validate calls the self validation methods of the struct value, adding their errors to the validation state.
+func (sv selfValidation) validate(e *Engine, value reflect.Value, structName string, structDepth uint8, state *validationState) {
+	if !sv.implemented() {
+		return
+	}
+	structType := value.Type()
+	if sv.pointerReceiver {
+		// the struct value is copied so the methods with a pointer receiver can be called on it.
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(value)
+		value = pointer
+	}
+	if !value.CanInterface() {
+		return
+	}
+	self := value.Interface()
+	if sv.validator {
+		if err := self.(SelfValidator).ValidateSelf(); err != nil {
+			state.addErrors(structName, withFieldName(err, structName))
+		}
+	}
+	if sv.reporter {
+		self.(SelfReporter).ReportSelf(&Reporter{
+			engine:      e,
+			structType:  structType,
+			structName:  structName,
+			structDepth: structDepth,
+			state:       state,
+		})
+	}
+}
+
+// withFieldName sets the Field of a *validator.FieldError that does not have one, so errors created without a field name are named after the field they are reported for.
+func withFieldName(err error, name string) error {
+	if fieldError, ok := err.(*validator.FieldError); ok && fieldError.Field == "" {
+		namedError := *fieldError
+		namedError.Field = name
+		return &namedError
+	}
+	return err
+}
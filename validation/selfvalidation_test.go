@@ -0,0 +1,122 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
+)
+
+var errDateRange = errors.New("the stay must end after it starts")
+
+type StayDates struct {
+	Start int `validate:"int,min=0"`
+	End   int `validate:"int,min=0"`
+}
+
+func (sd StayDates) ValidateSelf() error {
+	if sd.End <= sd.Start {
+		return errDateRange
+	}
+	return nil
+}
+
+type Reservation struct {
+	Guests int       `validate:"int,min=1"`
+	Nights int       `validate:"int,min=1"`
+	Dates  StayDates `validate:"struct"`
+	Rooms  []Room    `validate:"[]struct"`
+}
+
+// ReportSelf has a pointer receiver to test that pointer receivers are called.
+func (r *Reservation) ReportSelf(reporter *Reporter) {
+	if r.Nights > 14 && r.Guests > 4 {
+		reporter.Report("Nights", validator.NewFieldError("", "longstay", "", "14", r.Nights, "long stays are limited to 4 guests"))
+	}
+	reporter.Report("Guests", nil)
+}
+
+type Room struct {
+	Beds int `validate:"int,min=1"`
+}
+
+func (r Room) ReportSelf(reporter *Reporter) {
+	if r.Beds > 3 {
+		reporter.Report("Beds", errors.New("rooms have at most 3 beds"))
+	}
+}
+
+func TestSelfValidation(t *testing.T) {
+	testValue := Reservation{
+		Guests: 5,
+		Nights: 15,
+		Dates:  StayDates{Start: 3, End: 1},
+		Rooms:  []Room{{Beds: 2}, {Beds: 4}},
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	expected := []string{"Dates", "Rooms[1].Beds", "Nights"}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("the error keys should be %v but were %v", expected, keys)
+	}
+	if !errors.Is(validationError, errDateRange) {
+		t.Error("the ValidationError should contain the error from ValidateSelf")
+	}
+	fieldErrors := validationError.FieldErrors()
+	if len(fieldErrors) != 1 || fieldErrors[0].Field != "Nights" {
+		t.Error("the reported FieldError should be named after the field it was reported for", fieldErrors)
+	}
+}
+
+func TestSelfValidationValid(t *testing.T) {
+	testValue := Reservation{
+		Guests: 2,
+		Nights: 3,
+		Dates:  StayDates{Start: 1, End: 4},
+		Rooms:  []Room{{Beds: 2}},
+	}
+	if validationError := ValidateStructWithTag(testValue); validationError != nil {
+		t.Error("testValue should have passed validation", validationError.Error())
+	}
+}
+
+type JSONReservation struct {
+	Guests int        `json:"guests" validate:"int,min=1"`
+	Nights int        `json:"nights" validate:"int,min=1,max=30"`
+	Rooms  []JSONRoom `json:"rooms" validate:"[]struct"`
+}
+
+func (r JSONReservation) ReportSelf(reporter *Reporter) {
+	if r.Nights > 14 && r.Guests > 4 {
+		reporter.Report("Nights", errors.New("long stays are limited to 4 guests"))
+	}
+	if len(r.Rooms) > 0 && r.Rooms[0].Beds > 3 {
+		reporter.Report("Rooms[0].Beds", errors.New("rooms have at most 3 beds"))
+	}
+}
+
+type JSONRoom struct {
+	Beds int `json:"beds" validate:"int,min=1"`
+}
+
+func TestSelfReporterNameFunc(t *testing.T) {
+	testValue := JSONReservation{
+		Guests: 5,
+		Nights: 31,
+		Rooms:  []JSONRoom{{Beds: 4}},
+	}
+	validationError := New(WithNameFunc(JSONFieldName)).ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	expected := []string{"nights", "rooms[0].beds"}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("the reported errors should be named with the NameFunc, the error keys should be %v but were %v", expected, keys)
+	}
+	if errs := validationError.Errors["nights"]; len(errs) != 2 {
+		t.Errorf("nights should have the errors from its tag data and from ReportSelf: %v", errs)
+	}
+}
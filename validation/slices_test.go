@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Address struct {
+	City       string `validate:"string,required,min=2"`
+	PostalCode string `validate:"postalcode"`
+}
+
+type AddressBook struct {
+	Addresses []Address            `validate:"[]struct"`
+	Pointers  []*Address           `validate:"[]struct"`
+	Nested    [][2]Address         `validate:"[][]struct"`
+	Optional  *[]Address           `validate:"[]struct"`
+	ByName    map[string][]Address `validate:"values=[]struct"`
+}
+
+func TestSliceOfStructsValidation(t *testing.T) {
+	valid := Address{City: "Orlando", PostalCode: "32801"}
+	testValue := AddressBook{
+		Addresses: []Address{valid, valid, {City: "X", PostalCode: "32801"}},
+		Pointers:  []*Address{nil, {City: "Tampa", PostalCode: "bad"}},
+		Nested:    [][2]Address{{valid, valid}, {valid, {City: "", PostalCode: "32801"}}},
+		ByName:    map[string][]Address{"home": {{City: "X", PostalCode: "32801"}}},
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	expected := []string{"Addresses[2].City", "Pointers[1].PostalCode", "Nested[1][1].City", "ByName[home][0].City"}
+	if keys := validationError.orderedKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("the error keys should be %v but were %v", expected, keys)
+	}
+}
+
+func TestSliceOfStructsValid(t *testing.T) {
+	valid := Address{City: "Orlando", PostalCode: "32801"}
+	testValue := AddressBook{
+		Addresses: []Address{valid},
+		Pointers:  []*Address{&valid},
+		Optional:  &[]Address{valid},
+	}
+	if validationError := ValidateStructWithTag(testValue); validationError != nil {
+		t.Error("testValue should have passed validation", validationError.Error())
+	}
+}
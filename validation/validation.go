@@ -1,30 +1,32 @@
 package validation
 
 import (
-	"bytes"
+	"context"
 	"errors"
-	"fmt"
 	"reflect"
-	"strings"
+	"sort"
 
 	"github.com/calvine/simplevalidation/validation/validationparams"
 	"github.com/calvine/simplevalidation/validator"
-	"github.com/calvine/simplevalidation/validator/emailvalidator"
-	"github.com/calvine/simplevalidation/validator/floatvalidator"
-	"github.com/calvine/simplevalidation/validator/intvalidator"
-	"github.com/calvine/simplevalidation/validator/postalcodevalidator"
-	"github.com/calvine/simplevalidation/validator/stringvalidator"
-	"github.com/calvine/simplevalidation/validator/timevalidator"
-	"github.com/calvine/simplevalidation/validator/uintvalidator"
-	"github.com/calvine/simplevalidation/validator/uuidvalidator"
 )
 
 /*
 	ValidationError represents the overall validation state of a value.
 */
 type ValidationError struct {
+	// DataType is the name of the type of the value that was validated.
 	DataType string
-	Errors   validationErrorMap
+	// Errors contains the errors for each field that failed validation.
+	Errors validationErrorMap
+	// Format determines how Error formats the validation failures.
+	Format ErrorFormat
+	// Truncated is true when validation stopped before the whole value was validated, because the Engine's maximum number of errors was reached.
+	// The value may have more failures than the ones in Errors.
+	Truncated bool
+	// fields contains the keys of Errors in the order the fields were validated.
+	fields []string
+	// catalogs contains the message catalogs of the Engine that produced the error, see WithCatalog.
+	catalogs map[string]validator.Catalog
 }
 
 /*
@@ -36,56 +38,63 @@ type ValidationError struct {
 type validationErrorMap map[string][]error
 
 /*
-	Error produces a string that relays all of the validation failures from validation.
+	Error produces a string that relays all of the validation failures from validation, formatted according to the ValidationError Format.
+	The failures are in the order the fields were validated, which is the order the fields are declared in.
 */
 func (e *ValidationError) Error() string {
-	var errorBuffer bytes.Buffer
-	dataType := e.DataType
-	if dataType == "" {
-		dataType = "value"
-	}
-	headerLine := fmt.Sprintf("%s validation failed:", dataType)
-	fmt.Fprint(&errorBuffer, headerLine)
-	for key, err := range e.Errors {
-		for _, err2 := range err {
-			fmt.Fprintf(&errorBuffer, "\t%s: %s", key, err2.Error())
-		}
-	}
-	return errorBuffer.String()
+	return e.Render(e.Format)
 }
 
-const (
-	noValidatorWithNameErrorTemplate = "no validator: validator of type %s is not registered."
-	// pointerNilTemplate is the error message template then a required value is a pointer and also nil.
-	pointerNilErrorTemplate = "required: field %s was nil but is required"
-)
+/*
+	Unwrap returns every error in the ValidationError, in the order the fields were validated.
+	This allows errors.Is and errors.As to match any of the field errors, for instance:
 
-var (
-	// validators is a map contains a contains a validator.ValidatorFactory for each registered validator name.
-	validators map[string]validator.ValidatorFactory = map[string]validator.ValidatorFactory{
-		"email":      emailvalidator.New,
-		"float":      floatvalidator.New,
-		"int":        intvalidator.New,
-		"uint":       uintvalidator.New,
-		"postalcode": postalcodevalidator.New,
-		"string":     stringvalidator.New,
-		"time":       timevalidator.New,
-		"uuid":       uuidvalidator.New,
+		errors.Is(validationError, validator.ErrRequired)
+*/
+func (e *ValidationError) Unwrap() []error {
+	allErrors := []error{}
+	for _, key := range e.orderedKeys() {
+		allErrors = append(allErrors, e.Errors[key]...)
 	}
-)
+	return allErrors
+}
 
-// getValidatorFromTag Takes in the validator name from the tag data and returns an instance of the appropriate validator.
-// When the validatorName parameter is not registererd in the validators map, the function returns an error.
-func getValidatorFromTag(validatorName, fieldName string) (validator.Validator, error) {
-	if validatorName == "struct" {
-		return nil, nil
+// orderedKeys returns the keys of the Errors map in the order the fields were validated.
+// Any keys added to Errors after validation are returned last in sorted order.
+func (e *ValidationError) orderedKeys() []string {
+	keys := make([]string, 0, len(e.Errors))
+	seen := make(map[string]bool, len(e.Errors))
+	for _, key := range e.fields {
+		if _, ok := e.Errors[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	extraKeys := []string{}
+	for key := range e.Errors {
+		if !seen[key] {
+			extraKeys = append(extraKeys, key)
+		}
 	}
-	typeValidatorFactory, ok := validators[validatorName]
-	if !ok {
-		errMsg := fmt.Sprintf(noValidatorWithNameErrorTemplate, validatorName)
-		return nil, errors.New(errMsg)
+	sort.Strings(extraKeys)
+	return append(keys, extraKeys...)
+}
+
+/*
+	FieldErrors returns every *validator.FieldError in the ValidationError, in the order the fields were validated.
+	Errors that are not a *validator.FieldError, for instance from a custom validator, are not included.
+*/
+func (e *ValidationError) FieldErrors() []*validator.FieldError {
+	fieldErrors := []*validator.FieldError{}
+	for _, key := range e.orderedKeys() {
+		for _, err := range e.Errors[key] {
+			var fieldError *validator.FieldError
+			if errors.As(err, &fieldError) {
+				fieldErrors = append(fieldErrors, fieldError)
+			}
+		}
 	}
-	return typeValidatorFactory(), nil
+	return fieldErrors
 }
 
 // getValidatorInfo reads in the raw validator name from the tag data, and parses pairs of square brackets to determin the ArrayDepth of the value being validated.
@@ -93,178 +102,70 @@ func getValidatorFromTag(validatorName, fieldName string) (validator.Validator,
 func getValidatorInfo(validatorName string) (name string, arrayDepth uint8) {
 	arrayDepth = 0
 	tagNameStartIndex := 0
-	if len(validatorName) > 2 {
-		for {
-			if validatorName[tagNameStartIndex] == '[' && validatorName[tagNameStartIndex+1] == ']' {
-				arrayDepth++
-				tagNameStartIndex += 2
-			} else {
-				break
-			}
-		}
+	// the bounds are checked on each pair, so tag data made only of square bracket pairs like [][] returns an empty name.
+	for tagNameStartIndex+1 < len(validatorName) && validatorName[tagNameStartIndex] == '[' && validatorName[tagNameStartIndex+1] == ']' {
+		arrayDepth++
+		tagNameStartIndex += 2
 	}
 	return validatorName[tagNameStartIndex:], arrayDepth
 }
 
-/*
-	performFieldValidation is the core of the validation work flow. it takes a ValidationParams struct and a pointer to a validationErrorMap.
- 	It then proceeds to call its self recursivly, until all validation is completed. Upon completion the validationErrors parameter is populated with all errors arising from validation.
+// Validate validates a single value using the default Engine. See Engine.Validate for more information.
+func Validate(v *validationparams.ValidationParams) (*ValidationError, error) {
+	return defaultEngine.Validate(v)
+}
 
-	This function handles the following cases:
-		- When the value being validated is a pointer it is dereferenced, and the validated.
-			- When that pointer is nil validation is skipped, unless the validationparams.ValidationParams.Required field is true, then it will register a validation error.
-		- When the field being validated is a struct the struct fields are traversed and the function attempts to build the appropriate validator based on the validator tag data.
-		- When the field is any other kind it will attempt to validate the value, if the validationparams.ValidationParams.ArrayDepth is greater than 0 the function will iterate of the array / slice and validate each value for each level of array / slice.
-*/
-func performFieldValidation(validationInfo validationparams.ValidationParams, validationErrors *validationErrorMap) {
-	fieldErrors := []error{}
-	value := reflect.ValueOf(validationInfo.Value)
-	kind := value.Kind()
-	vType := value.Type()
-	// fmt.Printf("%v - %v\n\n", kind, vType.String())
-	if kind == reflect.Ptr {
-		// handle pointers.
-		isNil := value.IsNil()
-		if !validationInfo.Required && isNil {
-			// If there is only 1 tag arg, its the validation type.
-			// If required is not the second arg then its not required.
-			return
-		} else if validationInfo.Required && isNil {
-			errorMessage := fmt.Sprintf(pointerNilErrorTemplate, validationInfo.Name)
-			fieldErrors = append(fieldErrors, errors.New(errorMessage))
-		} else {
-			fieldValue := value.Elem().Interface()
-			recursiveFieldValidator := validationparams.ValidationParams{
-				ArrayDepth:     validationInfo.ArrayDepth,
-				Name:           validationInfo.Name,
-				FieldValidator: validationInfo.FieldValidator,
-				Required:       validationInfo.Required,
-				StructDepth:    validationInfo.StructDepth,
-				Value:          fieldValue,
-			}
-			performFieldValidation(recursiveFieldValidator, validationErrors)
-		}
-	} else if kind == reflect.Struct && validationInfo.FieldValidator == nil {
-		// handle structs and embedded structs.
-		structDepth := validationInfo.StructDepth + 1
-		for i := 0; i < value.NumField(); i++ {
-			field := vType.Field(i)
-			tag := field.Tag.Get("validate")
-			// fieldKind := field.Type.Kind()
-			// fieldType := field.Type
-			// fmt.Printf("k: %v - t: %v\n\n", fieldKind, fieldType)
-			if tag == "" || tag == "-" {
-				continue
-			}
-			fieldName := field.Name
-			if structDepth > 1 {
-				// this is specifically for structs within structs to create a better reference to the name of the field being validated.
-				fieldName = fmt.Sprintf("%s.%s", validationInfo.Name, fieldName)
-			}
-			fieldValue := value.Field(i)
-			tagArgs := strings.Split(tag, ",")
-			required := len(tagArgs) > 1 && tagArgs[1] == "required"
-			// TODO: Add struct case as special key word?
-			validatorName, arrayDepth := getValidatorInfo(tagArgs[0])
-			validator, err := getValidatorFromTag(validatorName, fieldName)
-			if err != nil {
-				// make a custom type not registered error?
-				fieldErrors = append(fieldErrors, err)
-				// should we panic?
-			} else if validator == nil {
-				validationData := validationparams.ValidationParams{
-					ArrayDepth:     arrayDepth,
-					FieldValidator: validator,
-					Name:           fieldName,
-					Required:       required,
-					StructDepth:    structDepth,
-					Value:          fieldValue.Interface(),
-				}
-				performFieldValidation(validationData, validationErrors)
-			} else {
-				err = validator.ReadOptionsFromTagItems(tagArgs[1:])
-				if err != nil {
-					// make a custom tag invalid error?
-					fieldErrors = append(fieldErrors, err)
-				}
-				validationData := validationparams.ValidationParams{
-					ArrayDepth:     arrayDepth,
-					FieldValidator: validator,
-					Name:           fieldName,
-					Required:       required,
-					StructDepth:    structDepth,
-					Value:          fieldValue.Interface(),
-				}
-				performFieldValidation(validationData, validationErrors)
-			}
-		}
-	} else if validationInfo.FieldValidator != nil {
-		// perform normal field validation.
-		if validationInfo.ArrayDepth == 0 {
-			_, fieldError := validationInfo.FieldValidator.Validate(validationInfo.Value, validationInfo.Name, kind)
-			if fieldError != nil {
-				fieldErrors = append(fieldErrors, fieldError)
-			}
-		} else {
-			// potentially nested array element validation.
-			currentArrayDepth := validationInfo.ArrayDepth
-			// kind := reflect.TypeOf(validationInfo.Value).Kind()
-			switch kind {
-			case reflect.Slice, reflect.Array:
-				currentArrayDepth--
-				currentLevelSlice := reflect.ValueOf(validationInfo.Value)
-				for i := 0; i < currentLevelSlice.Len(); i++ {
-					performFieldValidation(validationparams.ValidationParams{
-						ArrayDepth:     currentArrayDepth,
-						FieldValidator: validationInfo.FieldValidator,
-						Name:           fmt.Sprintf("%s[%d]", validationInfo.Name, i),
-						Required:       validationInfo.Required,
-						StructDepth:    validationInfo.StructDepth,
-						Value:          currentLevelSlice.Index(i).Interface(),
-					}, validationErrors)
-				}
-			default:
-				// This should not happen. add error...
-			}
-		}
-	} // else { panic? }
-	if len(fieldErrors) > 0 {
-		(*validationErrors)[validationInfo.Name] = fieldErrors
-	}
+// ValidateStructWithTag validates an input struct based on its validation tag data using the default Engine.
+func ValidateStructWithTag(s interface{}) *ValidationError {
+	return defaultEngine.ValidateStructWithTag(s)
 }
 
-//The Validator parameter is present to allow for validating non struct values. In this function A Validator pointer can be passed in and evaluated on a non struct value like an individual int or string.
-func Validate(v *validationparams.ValidationParams) (*ValidationError, error) {
-	validationErrors := validationErrorMap{}
-	if v == nil {
-		return nil, errors.New("no FieldValidationData provided")
-	}
-	performFieldValidation(*v, &validationErrors)
-	if len(validationErrors) > 0 {
-		return &ValidationError{
-			Errors: validationErrors,
-		}, nil
-	}
-	return nil, nil
+// ValidateGroups validates an input struct based on its validation tag data using the default Engine, applying only the fields and rules in the provided validation groups. See Engine.ValidateGroups for more information.
+func ValidateGroups(s interface{}, groups ...string) *ValidationError {
+	return defaultEngine.ValidateGroups(s, groups...)
 }
 
-// This function validates an input struct based on the validation tags is has in its tag data.
-func ValidateStructWithTag(s interface{}) *ValidationError {
-	validationErrors := validationErrorMap{}
-	validationData := validationparams.New()
-	validationData.Value = s
-	// default name for value being validated.
-	validationData.Name = "value"
-	performFieldValidation(validationData, &validationErrors)
-	if len(validationErrors) > 0 {
-		return &ValidationError{
-			Errors: validationErrors,
-		}
-	}
-	return nil
+// ValidatePartial validates only the fields of an input struct named by the provided dotted paths using the default Engine. See Engine.ValidatePartial for more information.
+func ValidatePartial(s interface{}, paths ...string) *ValidationError {
+	return defaultEngine.ValidatePartial(s, paths...)
+}
+
+// ValidateExcept validates the fields of an input struct not named by the provided dotted paths using the default Engine. See Engine.ValidateExcept for more information.
+func ValidateExcept(s interface{}, paths ...string) *ValidationError {
+	return defaultEngine.ValidateExcept(s, paths...)
+}
+
+// ValidateStructWithTagContext validates an input struct based on its validation tag data using the default Engine. See Engine.ValidateCtx for more information.
+func ValidateStructWithTagContext(ctx context.Context, s interface{}) (*ValidationError, error) {
+	return defaultEngine.ValidateCtx(ctx, s)
+}
+
+// Check checks the tag data of a struct type using the default Engine. See Engine.Check for more information.
+func Check(structType reflect.Type) error {
+	return defaultEngine.Check(structType)
+}
+
+// MustCompile checks and compiles the tag data of the type of the value using the default Engine, and panics when the tag data has problems. See Engine.MustCompile for more information.
+func MustCompile(v interface{}) {
+	defaultEngine.MustCompile(v)
+}
+
+// RegisterValidator registers a custom validator with the default Engine, so it can be read from struct field tag validation data.
+func RegisterValidator(name string, customValidatorFactory validator.ValidatorFactory) error {
+	return defaultEngine.RegisterValidator(name, customValidatorFactory)
+}
+
+// RegisterAlias makes alias usable in tag data in place of the validator registered with the default Engine under name.
+func RegisterAlias(alias, name string) error {
+	return defaultEngine.RegisterAlias(alias, name)
+}
+
+// UnregisterValidator removes the validator or alias registered with the default Engine under name.
+func UnregisterValidator(name string) bool {
+	return defaultEngine.UnregisterValidator(name)
 }
 
-// This allows you to register custom validator to be read from struct field tag validatoin data.
-func RegisterValidator(name string, customValidatorFactory validator.ValidatorFactory) {
-	validators[name] = customValidatorFactory
+// RegisteredValidators returns the sorted names of the validators registered with the default Engine.
+func RegisteredValidators() []string {
+	return defaultEngine.RegisteredValidators()
 }
@@ -1,17 +1,20 @@
 package validation
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/calvine/simplevalidation/validator"
 	"github.com/google/uuid"
 )
 
 // This is for testing embedded structs
 type OtherThing struct {
 	ID          int    `validate:"int,required,min=3"`
-	Description string `validate:"string,min=15,max=150"`
+	Description string `validate:"string,omitempty,min=15,max=150"`
 }
 
 // this is for testing fields with struct values
@@ -30,7 +33,7 @@ type SimpleItem struct {
 
 type TestStruct struct {
 	Arry       *[]int     `validate:"[]int,min=0,max=150"`
-	ID         uuid.UUID  `validate:"uuid,required,allowemptyuuid"`
+	ID         uuid.UUID  `validate:"uuid,allowemptyuuid"`
 	ID2        *uuid.UUID `validate:"uuid"`
 	Age        int        `validate:"int,min=0,max=150"`
 	Email      string     `validate:"email,required"`
@@ -109,3 +112,59 @@ func TestNoValidatorRegistered(t *testing.T) {
 
 	}
 }
+
+func TestFieldErrors(t *testing.T) {
+	testValue := struct {
+		Name    string  `validate:"string,min=5"`
+		Age     int     `validate:"int,max=150"`
+		Pointer *string `validate:"string,required"`
+		Other   string  `validate:"notarealvalidator"`
+	}{
+		Name: "abc",
+		Age:  200,
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	codes := map[string]string{}
+	for _, fieldError := range validationError.FieldErrors() {
+		codes[fieldError.Field] = fieldError.Code
+	}
+	expected := map[string]string{
+		"Name":    validator.CodeMin,
+		"Age":     validator.CodeMax,
+		"Pointer": validator.CodeRequired,
+		"Other":   validator.CodeNoValidator,
+	}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Errorf("FieldErrors codes should be %v but were %v", expected, codes)
+	}
+}
+
+func TestValidationErrorIs(t *testing.T) {
+	testValue := struct {
+		Name    string  `validate:"string,min=5"`
+		Pointer *string `validate:"string,required"`
+		Other   string  `validate:"notarealvalidator"`
+	}{
+		Name: "abc",
+	}
+	validationError := ValidateStructWithTag(testValue)
+	if validationError == nil {
+		t.Fatal("testValue should have failed validation")
+	}
+	var err error = validationError
+	for _, sentinel := range []error{validator.ErrMin, validator.ErrRequired, validator.ErrNoValidator} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("validationError should wrap %v", sentinel)
+		}
+	}
+	if errors.Is(err, validator.ErrMax) {
+		t.Error("validationError should not wrap validator.ErrMax")
+	}
+	var fieldError *validator.FieldError
+	if !errors.As(err, &fieldError) {
+		t.Error("errors.As should find a *validator.FieldError in validationError")
+	}
+}
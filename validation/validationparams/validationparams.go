@@ -31,9 +31,19 @@ type ValidationParams struct {
 	*/
 	Name string
 	/*
-		Required is a special validation cruteria that is technically valid for any validator. If the Validator does not directly use the required flag, it is still used in the event that the value being validated is a pointer. If the value being validated is a pointer and that pointer is nil then that will result in a validation error when required is true.
+		Required is a special validation cruteria that is valid for any validator, and is checked by the validation package instead of the Validator.
+		When required is true a value that is empty, which is the zero value for its type like 0, false, "" or a nil pointer, or a slice or map with no elements, will result in a validation error, and the Validator is not called.
+		Only the value itself is required, so a pointer that is not nil is provided even when it points to a zero value, and the elements of a slice are not required.
 	*/
 	Required bool
+	/*
+		OmitEmpty skips every validation rule when the value is empty, so a value is only validated when it is provided. It cannot be combined with Required.
+	*/
+	OmitEmpty bool
+	/*
+		Nullable allows a nil pointer even when Required is true, so the value may be explicitly null. When the pointer is not nil the value it points to is validated, even when it is the zero value for its type.
+	*/
+	Nullable bool
 	/*
 		This is a counter that keeps track of how many structs deep validation is being performed.
 
@@ -65,6 +75,8 @@ func New() ValidationParams {
 		ArrayDepth:  0,
 		Name:        "",
 		Required:    false,
+		OmitEmpty:   false,
+		Nullable:    false,
 		StructDepth: 0,
 	}
 }
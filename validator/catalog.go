@@ -0,0 +1,190 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultLocale is the locale of the built in English catalog, used when no catalog is registered for a locale.
+	DefaultLocale = "en"
+	// ValidationFailedKey is the catalog key for the header line of a rendered validation error. Its only parameter is {type}.
+	ValidationFailedKey = "validationfailed"
+	// ValidationTruncatedKey is the catalog key for the line added to a rendered validation error when validation stopped before the whole value was validated. It has no parameters.
+	ValidationTruncatedKey = "validationtruncated"
+)
+
+/*
+Catalog provides the message templates used to render errors for a locale.
+
+Templates are looked up by key. For a FieldError its Key is tried first when it has one, then "validatorname.code" (for instance "string.min"), then the code on its own (for instance "min").
+Templates use named parameters wrapped in braces. Every FieldError provides these parameters:
+
+	{field}     the path to the field that failed validation
+	{param}     the tag parameter of the rule that failed
+	{value}     the value that failed validation
+	{validator} the name of the validator
+	{code}      the rule code
+
+along with any details added by the validator with FieldError.WithDetail, for instance {length} for the string validator.
+*/
+type Catalog interface {
+	// Template returns the message template for the key, or false if the catalog has no template for the key.
+	Template(key string) (string, bool)
+}
+
+// MapCatalog is a Catalog backed by a map of keys to message templates.
+type MapCatalog map[string]string
+
+// Template returns the message template for the key, or false if the catalog has no template for the key.
+func (mc MapCatalog) Template(key string) (string, bool) {
+	template, ok := mc[key]
+	return template, ok
+}
+
+var (
+	// English is the built in catalog, registered for DefaultLocale.
+	English = MapCatalog{
+		ValidationFailedKey:    "{type} validation failed:",
+		ValidationTruncatedKey: "validation stopped early, other fields may also be invalid",
+
+		CodeRequired:    "required: the field {field} is required",
+		CodeExcluded:    "excluded: the field {field} must be empty",
+		CodeMin:         "min: the field {field} value {value} is less than the minimum value {param}",
+		CodeMax:         "max: the field {field} value {value} is greater than the maximum value {param}",
+		CodeLen:         "len: the field {field} does not have the length {param}",
+		CodeUnique:      "unique: the field {field} contains the repeated value {value}",
+		CodeEqField:     "eqfield: the field {field} must be equal to the field {param}",
+		CodeNeField:     "nefield: the field {field} must not be equal to the field {param}",
+		CodeGtField:     "gtfield: the field {field} value {value} must be greater than the field {param} value {other}",
+		CodeGteField:    "gtefield: the field {field} value {value} must be greater than or equal to the field {param} value {other}",
+		CodeLtField:     "ltfield: the field {field} value {value} must be less than the field {param} value {other}",
+		CodeLteField:    "ltefield: the field {field} value {value} must be less than or equal to the field {param} value {other}",
+		CodeType:        "type: the value of {field} is of type {type} which is not valid",
+		CodeInvalid:     "invalid: the field {field} has the invalid value '{value}'",
+		CodeLookup:      "lookup: the field {field} could not be validated because a lookup failed",
+		CodeNoValidator: "no validator: validator of type {validator} is not registered.",
+		CodeBadTag:      "bad tag: the tag data for the field {field} is not valid: {error}",
+
+		"string.min": "min length: the value of {field} is {value} of length {length} which is less than the minimum length {param}",
+		"string.max": "max length: the value of {field} is {value} of length {length} which is greater than the maximum length {param}",
+
+		"required_if":      "required: the field {field} is required when the fields have the values {param}",
+		"required_unless":  "required: the field {field} is required unless the fields have the values {param}",
+		"required_with":    "required: the field {field} is required when any of these fields are set: {param}",
+		"required_without": "required: the field {field} is required when any of these fields are not set: {param}",
+		"excluded_if":      "excluded: the field {field} must be empty when the fields have the values {param}",
+
+		"collection.min":      "min length: the field {field} has {length} items which is less than the minimum of {param}",
+		"collection.max":      "max length: the field {field} has {length} items which is greater than the maximum of {param}",
+		"collection.len":      "length: the field {field} has {length} items but must have exactly {param}",
+		"collection.notempty": "not empty: the field {field} must have at least one item",
+		"collection.unique":   "unique: the field {field} contains the value {value} more than once, first repeated at index {index}",
+
+		"int.max": "max: The field {field} value {value} is greater than the maximum value {param}",
+
+		"email.invalid":   "invalid: the field {field} does cont contain a valid email. '{value}' was provided",
+		"email.mxmissing": "mx missing: The field {field} had no MX records found for domain {domain}",
+		"email.lookup":    "mx error: The field {field} encountered an error occurred while validating domain MX record for domain {domain}. Error: {error}",
+
+		"postalcode.invalid": "invalid: the field {field} does cont contain a valid postal code. '{value}' was provided",
+
+		"uuid.allowstring":    "type: The field {field} is a string, but allowstring was not provided in the validation tag",
+		"uuid.invalid":        "invalid: the field {field} has the value {value} which could not be parsed into a UUID",
+		"uuid.allowemptyuuid": "no empty: the field {field} has an empty uuid value, but AllowEmptyUUID is false",
+
+		"time.allowint": "type: the field {field} is an int, but AllowInt was false",
+		"time.min":      "not before: the field {field} has a value of '{time}' which is before '{limit}'",
+		"time.max":      "not after: the field {field} has a value of '{time}' which is after '{limit}'",
+	}
+)
+
+var (
+	catalogsMutex sync.RWMutex
+	// catalogs contains the Catalog registered for each locale.
+	catalogs = map[string]Catalog{
+		DefaultLocale: English,
+	}
+)
+
+// RegisterCatalog registers the Catalog used to render errors for the locale, replacing any Catalog already registered for it.
+func RegisterCatalog(locale string, catalog Catalog) {
+	catalogsMutex.Lock()
+	defer catalogsMutex.Unlock()
+	catalogs[locale] = catalog
+}
+
+// UnregisterCatalog removes the Catalog registered for the locale, so its errors are rendered with the English catalog again.
+func UnregisterCatalog(locale string) {
+	catalogsMutex.Lock()
+	defer catalogsMutex.Unlock()
+	delete(catalogs, locale)
+}
+
+// CatalogFor returns the Catalog registered for the locale, or false if no Catalog is registered for it.
+func CatalogFor(locale string) (Catalog, bool) {
+	catalogsMutex.RLock()
+	defer catalogsMutex.RUnlock()
+	catalog, ok := catalogs[locale]
+	return catalog, ok
+}
+
+// LookupTemplate finds the template for the first key that has one, first in the Catalog for the locale and then in the English catalog.
+func LookupTemplate(locale string, keys ...string) (string, bool) {
+	catalog, _ := CatalogFor(locale)
+	return LookupCatalogTemplate(catalog, keys...)
+}
+
+// LookupCatalogTemplate finds the template for the first key that has one, first in the catalog and then in the English catalog. The catalog may be nil, in which case only the English catalog is used.
+func LookupCatalogTemplate(catalog Catalog, keys ...string) (string, bool) {
+	if catalog != nil {
+		for _, key := range keys {
+			if template, ok := catalog.Template(key); ok {
+				return template, true
+			}
+		}
+	}
+	for _, key := range keys {
+		if template, ok := English.Template(key); ok {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+// RenderTemplate replaces each {name} parameter in the template with its value from params. Parameters that are not in params are left as they are.
+func RenderTemplate(template string, params map[string]string) string {
+	if !strings.Contains(template, "{") {
+		return template
+	}
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// LocalizeError renders the error for the locale when it is a *FieldError, and returns err.Error() for any other error.
+func LocalizeError(err error, locale string) string {
+	catalog, _ := CatalogFor(locale)
+	return LocalizeCatalogError(err, catalog)
+}
+
+// LocalizeCatalogError renders the error with the catalog when it is a *FieldError, and returns err.Error() for any other error. The catalog may be nil, see LookupCatalogTemplate.
+func LocalizeCatalogError(err error, catalog Catalog) string {
+	var fieldError *FieldError
+	if errors.As(err, &fieldError) {
+		return fieldError.LocalizeCatalog(catalog)
+	}
+	return err.Error()
+}
+
+// formatValue formats a value for use as the {value} parameter.
+func formatValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
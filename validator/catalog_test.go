@@ -0,0 +1,75 @@
+package validator
+
+import "testing"
+
+func TestRenderTemplate(t *testing.T) {
+	rendered := RenderTemplate("{field} must be at least {param} but was {value} {missing}", map[string]string{
+		"field": "Age",
+		"param": "18",
+		"value": "3",
+	})
+	expected := "Age must be at least 18 but was 3 {missing}"
+	if rendered != expected {
+		t.Errorf("the rendered template should be '%s' but was '%s'", expected, rendered)
+	}
+}
+
+func TestFieldErrorLocalize(t *testing.T) {
+	RegisterCatalog("es", MapCatalog{
+		CodeMin:      "mín: el campo {field} con valor {value} es menor que {param}",
+		"string.min": "longitud mínima: {field} debe tener al menos {param} caracteres",
+	})
+	defer UnregisterCatalog("es")
+	intError := NewFieldError("int", CodeMin, "Age", "18", 3, "")
+	if localized := intError.Localize("es"); localized != "mín: el campo Age con valor 3 es menor que 18" {
+		t.Error("the int error should use the generic es min template:", localized)
+	}
+	stringError := NewFieldError("string", CodeMin, "Name", "5", "abc", "").WithDetail("length", "3")
+	if localized := stringError.Localize("es"); localized != "longitud mínima: Name debe tener al menos 5 caracteres" {
+		t.Error("the string error should use the es string.min template:", localized)
+	}
+	if localized := stringError.Localize("en"); localized != "min length: the value of Name is abc of length 3 which is less than the minimum length 5" {
+		t.Error("the string error should use the English string.min template:", localized)
+	}
+	maxError := NewFieldError("int", CodeMax, "Age", "150", 200, "")
+	if localized := maxError.Localize("es"); localized != maxError.Localize(DefaultLocale) {
+		t.Error("a key missing from the es catalog should fall back to the English catalog:", localized)
+	}
+	if localized := maxError.Localize("fr"); localized != maxError.Error() {
+		t.Error("a locale with no catalog should fall back to the English catalog:", localized)
+	}
+}
+
+func TestFieldErrorMessageOverridesCatalog(t *testing.T) {
+	fieldError := NewFieldError("int", CodeMin, "Age", "18", 3, "custom message")
+	if fieldError.Error() != "custom message" || fieldError.Localize("es") != "custom message" {
+		t.Error("Message should be used instead of the catalog template")
+	}
+}
+
+func TestUnregisterCatalog(t *testing.T) {
+	RegisterCatalog("it", MapCatalog{CodeMax: "{field} supera {param}"})
+	defer UnregisterCatalog("it")
+	maxError := NewFieldError("int", CodeMax, "Age", "150", 200, "")
+	if localized := maxError.Localize("it"); localized != "Age supera 150" {
+		t.Error("the it catalog should be used while it is registered:", localized)
+	}
+	UnregisterCatalog("it")
+	if _, ok := CatalogFor("it"); ok {
+		t.Error("the it catalog should not be registered after UnregisterCatalog")
+	}
+	if localized := maxError.Localize("it"); localized != maxError.Error() {
+		t.Error("an unregistered locale should fall back to the English catalog:", localized)
+	}
+}
+
+func TestLocalizeCatalog(t *testing.T) {
+	catalog := MapCatalog{CodeMin: "{field} es menor que {param}"}
+	minError := NewFieldError("int", CodeMin, "Age", "18", 3, "")
+	if localized := minError.LocalizeCatalog(catalog); localized != "Age es menor que 18" {
+		t.Error("the catalog template should be used:", localized)
+	}
+	if localized := minError.LocalizeCatalog(nil); localized != minError.Error() {
+		t.Error("a nil catalog should fall back to the English catalog:", localized)
+	}
+}
@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	// TypeMismatchErrorTemplate is a fmt template for the error returned by TypeChecker.CheckType, see NewTypeMismatchError.
+	TypeMismatchErrorTemplate = "%w: the %s validator cannot validate a value of type %s"
+)
+
+// OptionSpec describes an option a validator accepts in tag data.
+type OptionSpec struct {
+	// Key is the name of the option in tag data, for instance "min" for min=3.
+	Key string
+	// Description is a short description of the option.
+	Description string
+	// Flag is true when the option is used without a value, like allowint. Otherwise the option requires a value, like min=3.
+	Flag bool
+	// AllowsZero is true when the option makes the zero value of the type valid, like allowemptyuuid, which the required option of the validation package always rejects.
+	AllowsZero bool
+}
+
+/*
+	OptionDescriber is an optional interface for validators that declare the options they accept in tag data.
+
+	Tag data can then be checked before any value is validated, so an option key that the validator does not accept, like mn=3, is reported instead of being silently ignored.
+	Options read by the validation package itself, like required and msg, do not have to be declared.
+*/
+type OptionDescriber interface {
+	Options() []OptionSpec
+}
+
+/*
+	TypeChecker is an optional interface for validators that declare the types they can validate.
+
+	CheckType is called after the options have been read from tag data, so the accepted types can depend on them, like the allowint option of the time validator.
+	It is called with the type of the value the validator receives, so for a pointer field it is the type the pointer points to, and for a slice field with [] in the tag data it is the element type.
+*/
+type TypeChecker interface {
+	CheckType(t reflect.Type) error
+}
+
+// NewTypeMismatchError creates the error returned by TypeChecker.CheckType when the validator cannot validate values of the type. It wraps ErrType.
+func NewTypeMismatchError(validatorName string, t reflect.Type) error {
+	return fmt.Errorf(TypeMismatchErrorTemplate, ErrType, validatorName, t.String())
+}
+
+// CheckTypeIs returns an error created with NewTypeMismatchError unless the type is one of the accepted types.
+func CheckTypeIs(validatorName string, t reflect.Type, accepted ...reflect.Type) error {
+	for _, acceptedType := range accepted {
+		if t == acceptedType {
+			return nil
+		}
+	}
+	return NewTypeMismatchError(validatorName, t)
+}
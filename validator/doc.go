@@ -17,12 +17,87 @@
 		- The first parameter is always the validator name as registered in the validators map in the validation package.
 			- There is a special case when validating an embedded struct, you need the tag data, but for the validator name you need to add "struct" like below:
 				- `validate:"struct"`
+			- Slices and arrays of structs or struct pointers are validated with "[]struct", and errors for their fields are named like "Addresses[2].City".
 			- You can still have the required parameter in the tag data also, and if the underlying field is a pointer then the normal required rules for a pointer apply.
-		- The second parameter is the validator parameters, if you are using the required parameter for any validator, it bus the the second parameter in the tag data to be registered properly.
-		- After than, any additional validator parameters that you may need
+		- After that, any validator parameters that you may need, in any order.
+		- The required parameter can be anywhere after the validator name, and works the same for every validator: the field must not be empty, which is the zero value for its type like 0, false, "" or a nil pointer, or a slice or map with no elements.
+			- Validators do not read the required parameter, it is checked by the validation package before the validator is called.
+			- Since required always rejects the zero value, it cannot be combined with an option that allows the zero value. Before required was checked by the validation package `validate:"uuid,required,allowemptyuuid"` allowed the empty uuid, now it rejects it, so drop required to allow the empty uuid. Check reports the combination.
+			- Only the field itself is required, so a pointer that is not nil is provided even when it points to 0, and `validate:"[]int,required,min=0"` allows []int{0, 5}.
+		- The omitempty parameter skips every rule when the field is empty, so the field is only validated when it is provided, and it cannot be combined with required.
+			- `validate:"int,omitempty,min=18"` allows 0, but not 5.
+		- The nullable parameter allows a pointer field to be nil even when it is required. When the pointer is not nil the rules apply to the value it points to.
+			- `validate:"string,required,nullable,min=3"` allows nil, but not a pointer to "ab".
+		- Without required or omitempty an empty field is validated like any other value, except for a nil pointer which is not validated. So string min=1 fails for "", the same way int min=1 fails for 0.
+
+	The keys and values of a map are validated with a map tag, made of sections separated by semicolons.
+	The keys= section holds the tag data for the keys, the values= section holds the tag data for the values and an optional first map section holds the parameters for the map itself:
+
+		`validate:"map,required;keys=string,max=20;values=int,min=0"`
+
+	Errors for a map entry are named after the key, for instance "Limits[foo]", and maps of structs are validated with values=struct.
+
+	Slices, arrays and maps can also have collection rules, which are evaluated on the collection itself before its elements are validated, at each [] depth:
+
+		`validate:"[]string,minlen=1,maxlen=10,unique,max=20"`
+
+	The collection rules are len=N, minlen=N, maxlen=N, notempty, unique and unique=FieldName, which compares the named field of each struct element.
+	These keys are only collection rules in tag data with [] pairs or in the first section of a map tag. Otherwise they are passed to the validator like any other option, so a validator can have its own len option for a scalar value.
+
+	Cross field rules compare a field with another field of the same struct, named by its Go field name, or with a path from the top level struct when the name starts with "$.":
+
+		`validate:"time,gtfield=StartTime"`
+		`validate:"string,eqfield=Password"`
+		`validate:"int,ltefield=$.Limits.MaxGuests"`
+
+	The cross field rules are eqfield, nefield, gtfield, gtefield, ltfield and ltefield. They compare numbers, strings and time.Time values, and eqfield and nefield can compare any values.
+
+	Conditional rules make a field required, or require it to be empty, depending on other fields:
+
+		`validate:"struct,required_if=DeliveryMethod ship"`
+		`validate:"string,required_without=Email"`
+
+	The conditional rules are required_if, required_unless, required_with, required_without and excluded_if. A field with conditional rules that is empty is not validated any further, unless it also has the required parameter.
+
+	Fields and rules can be assigned to validation groups, so one struct can be validated differently for different operations.
+	A field is assigned to groups with a groups tag or a bare @group item, and a single rule by appending @group to it:
+
+		`validate:"uuid,required" groups:"update"`
+		`validate:"uuid,required,@update"`
+		`validate:"uuid,required@update"`
+
+	Fields that are not assigned to a group are in the default group, which is the only group validated unless other groups are requested.
+
+	The message for every error of a field can be replaced with the msg parameter, and the message for a single rule code with a companion tag named after the tag key with "_msg" appended:
+
+		`validate:"string,min=3,msg=Please enter a name" validate_msg:"required=Please enter your postal code;min=At least {param} characters"`
+
+	Custom messages can use the same named parameters as a Catalog template, like {field}, {param} and {value}.
+
+	Values in tag data can be quoted with single quotes, so they can contain commas, semicolons, equal signs and spaces, and any character can be escaped with a backslash.
+	A list value, for validators that accept one, is surrounded by square brackets and its values are separated by spaces, see Option.List:
+
+		`validate:"string,min=3,msg='Please, enter a name'"`
+		`validate:"string,required_if=City 'New York'"`
+		`validate:"color,values=[red green 'dark blue']"`
+
+	Tag data that cannot be parsed, for instance because a quote is not terminated, is reported as a bad tag error with the column of the syntax error.
 
 	The validator parameters are the read by the above mentioned ReadOptionsFromTagItems function implemented by the validator matched by the validator name is the tag data.
+	Validators should read each item with ParseOption, which removes the quotes and escapes from the value, instead of splitting the items themselves.
+
+	Validators can implement OptionDescriber to declare the options they accept, and whether each is a flag or takes a value, and TypeChecker to declare the types they can validate, so the validation package can check tag data before any value is validated.
 
 	For examples of how a validator is implemented take a look at the various validators implemented in this package.
+
+	Validators report failures with a *FieldError, which carries the rule code, the field path, the tag parameter and the value that failed.
+	The message for a FieldError is rendered from a Catalog of message templates keyed by rule code, so messages can be translated:
+
+		validator.RegisterCatalog("es", validator.MapCatalog{
+			validator.CodeRequired: "el campo {field} es obligatorio",
+		})
+
+	Any template missing from a registered Catalog falls back to the built in English catalog.
+	RegisterCatalog registers the Catalog for the whole program, so the validation package also lets each Engine have its own catalogs with validation.WithCatalog, which keep registrations from leaking between Engines and tests.
 */
 package validator
@@ -1,8 +1,7 @@
 package emailvalidator
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"net"
 	"reflect"
 	"regexp"
@@ -15,14 +14,16 @@ import (
 type emailValidator struct {
 	// If true we check for a valid MX record for the domain of the email.
 	CheckDomainMX bool
-	Required      bool
 }
 
 const (
-	emailInvalidErrorTemplate          = "invalid: the field %s does cont contain a valid email. '%s' was provided"
-	emailRequiredErrorTemplate         = "required: the field %s is required"
-	emailDomainMXNotFoundErrorTemplate = "mx missing: The field %s had no MX records found for domain %s"
-	emailDomainMXLookupErrorTemplate   = "mx error: The field %s encountered an error occurred while validating domain MX record for domain %s. Error: %s"
+	// validatorName is the name the email validator is registered under in the validation package.
+	validatorName = "email"
+	// mxMissingKey is the catalog key for the error when the domain of the email has no MX records.
+	mxMissingKey = "email.mxmissing"
+
+	// checkDomainMXOption is the tag option that enables the domain MX record check.
+	checkDomainMXOption = "checkdomainmx"
 )
 
 var (
@@ -35,32 +36,26 @@ func New() validator.Validator {
 }
 
 func (ev *emailValidator) Validate(n interface{}, fieldName string, fieldKind reflect.Kind) (bool, error) {
+	return ev.ValidateContext(context.Background(), n, fieldName, fieldKind)
+}
+
+// ValidateContext validates the email, using the context for the domain MX record lookup so a slow resolver can be cancelled.
+func (ev *emailValidator) ValidateContext(ctx context.Context, n interface{}, fieldName string, fieldKind reflect.Kind) (bool, error) {
 	value, ok := n.(string)
 	if !ok {
-		errorMessage := fmt.Sprintf(validator.InvalidTypeErrorTemplate, fieldName, n)
-		return false, errors.New(errorMessage)
+		return false, validator.NewFieldError(validatorName, validator.CodeType, fieldName, "", n, "")
 	}
-	valueProvided := value != ""
-
-	if ev.Required && !valueProvided {
-		errorMessage := fmt.Sprintf(emailRequiredErrorTemplate, fieldName)
-		return false, errors.New(errorMessage)
-	}
-	if valueProvided {
-		if !emailValidationRegexp.Match([]byte(value)) {
-			errorMessage := fmt.Sprintf(emailInvalidErrorTemplate, fieldName, value)
-			return false, errors.New(errorMessage)
-		} else if ev.CheckDomainMX {
-			emailParts := strings.Split(value, "@")
-			domain := emailParts[1]
-			mx, err := net.LookupMX(emailParts[1])
-			if err != nil {
-				errorMessage := fmt.Sprintf(emailDomainMXLookupErrorTemplate, fieldName, domain, err.Error())
-				return false, errors.New(errorMessage)
-			} else if len(mx) == 0 {
-				errorMessage := fmt.Sprintf(emailDomainMXNotFoundErrorTemplate, fieldName, domain)
-				return false, errors.New(errorMessage)
-			}
+	// an empty string is not a valid email, use the omitempty option to allow it.
+	if !emailValidationRegexp.Match([]byte(value)) {
+		return false, validator.NewFieldError(validatorName, validator.CodeInvalid, fieldName, "", value, "")
+	} else if ev.CheckDomainMX {
+		emailParts := strings.Split(value, "@")
+		domain := emailParts[1]
+		mx, err := net.DefaultResolver.LookupMX(ctx, domain)
+		if err != nil {
+			return false, validator.NewFieldError(validatorName, validator.CodeLookup, fieldName, checkDomainMXOption, value, "").WithDetail("domain", domain).WithDetail("error", err.Error())
+		} else if len(mx) == 0 {
+			return false, validator.NewFieldError(validatorName, validator.CodeInvalid, fieldName, checkDomainMXOption, value, "").WithKey(mxMissingKey).WithDetail("domain", domain)
 		}
 	}
 	return true, nil
@@ -68,12 +63,26 @@ func (ev *emailValidator) Validate(n interface{}, fieldName string, fieldKind re
 
 func (ev *emailValidator) ReadOptionsFromTagItems(items []string) error {
 	for i := 0; i < len(items); i++ {
-		switch parts := strings.Split(items[i], "="); parts[0] {
-		case "required":
-			ev.Required = true
-		case "checkdomainmx":
+		option, err := validator.ParseOption(items[i])
+		if err != nil {
+			return err
+		}
+		switch option.Key {
+		case checkDomainMXOption:
 			ev.CheckDomainMX = true
 		}
 	}
 	return nil
 }
+
+// Options returns the options the email validator accepts in tag data.
+func (ev *emailValidator) Options() []validator.OptionSpec {
+	return []validator.OptionSpec{
+		{Key: checkDomainMXOption, Description: "check that the domain of the email has MX records", Flag: true},
+	}
+}
+
+// CheckType returns an error unless the type is string.
+func (ev *emailValidator) CheckType(t reflect.Type) error {
+	return validator.CheckTypeIs(validatorName, t, reflect.TypeOf(""))
+}
@@ -1,10 +1,13 @@
 package emailvalidator
 
 import (
+	"errors"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
 )
 
 const (
@@ -12,9 +15,7 @@ const (
 )
 
 func TestValidEmail(t *testing.T) {
-	tValidator := emailValidator{
-		Required: true,
-	}
+	tValidator := emailValidator{}
 	testValue := "user@domain.com"
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
@@ -23,15 +24,15 @@ func TestValidEmail(t *testing.T) {
 	}
 }
 
-func TestValidNotRequiredEmptyEmail(t *testing.T) {
-	tValidator := emailValidator{
-		Required: false,
-	}
+func TestInvalidEmptyEmail(t *testing.T) {
+	tValidator := emailValidator{}
 	testValue := ""
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
-	if !isValid {
-		t.Error("isValid should be true because Required is false and an empty string was provided: ", err.Error())
+	if isValid {
+		t.Error("isValid should be false because an empty string is not a valid email, the omitempty option allows it instead")
+	} else if !errors.Is(err, validator.ErrInvalidFormat) {
+		t.Errorf("err should wrap validator.ErrInvalidFormat: %v", err)
 	}
 }
 
@@ -53,23 +54,6 @@ func TestValidDomainMX(t *testing.T) {
 	}
 }
 
-func TestInvalidRequired(t *testing.T) {
-	tValidator := emailValidator{
-		Required: true,
-	}
-	testValue := ""
-	valueKind := reflect.TypeOf(testValue).Kind()
-	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
-	expectedErrorPrefix := "required:"
-	if isValid {
-		t.Error("isValid should be false because Required is true and the value provided is an empty string")
-	} else if err == nil {
-		t.Error("err should be populated")
-	} else if hasRightIndex := strings.Index(err.Error(), expectedErrorPrefix); hasRightIndex == -1 {
-		t.Errorf("err.Error() begin with '%s': %s", expectedErrorPrefix, err.Error())
-	}
-}
-
 func TestInvalidEmail(t *testing.T) {
 	tValidator := emailValidator{}
 	testValue := "not an email..."
@@ -155,9 +139,7 @@ func TestInvalidDomainMXErrorRecord(t *testing.T) {
 // }
 
 func TestInvalidType(t *testing.T) {
-	tValidator := emailValidator{
-		Required: true,
-	}
+	tValidator := emailValidator{}
 	testValue := uint(32)
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
@@ -177,9 +159,6 @@ func TestReadOptionsFromTagItemsAllParameters(t *testing.T) {
 	tagArgs := strings.Split(testTag, ",")
 	tValidator := emailValidator{}
 	tValidator.ReadOptionsFromTagItems(tagArgs[1:])
-	if tValidator.Required != true {
-		t.Error("Required should be true")
-	}
 	if tValidator.CheckDomainMX != true {
 		t.Error("CheckDomainMX should be true")
 	}
@@ -0,0 +1,205 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Rule codes are the stable identifiers for each kind of validation failure, used in FieldError.Code.
+const (
+	// CodeRequired is used when a required value is not provided.
+	CodeRequired = "required"
+	// CodeExcluded is used when a value must be empty but is not.
+	CodeExcluded = "excluded"
+	// CodeMin is used when a value (or its length) is less than the minimum allowed.
+	CodeMin = "min"
+	// CodeMax is used when a value (or its length) is greater than the maximum allowed.
+	CodeMax = "max"
+	// CodeLen is used when the length of a value is not the exact length required.
+	CodeLen = "len"
+	// CodeUnique is used when the elements of a collection must be unique and an element is repeated.
+	CodeUnique = "unique"
+	// CodeEqField is used when a value is not equal to the value of the field it is compared with.
+	CodeEqField = "eqfield"
+	// CodeNeField is used when a value is equal to the value of the field it is compared with.
+	CodeNeField = "nefield"
+	// CodeGtField is used when a value is not greater than the value of the field it is compared with.
+	CodeGtField = "gtfield"
+	// CodeGteField is used when a value is less than the value of the field it is compared with.
+	CodeGteField = "gtefield"
+	// CodeLtField is used when a value is not less than the value of the field it is compared with.
+	CodeLtField = "ltfield"
+	// CodeLteField is used when a value is greater than the value of the field it is compared with.
+	CodeLteField = "ltefield"
+	// CodeType is used when a value is not of a type the validator can validate.
+	CodeType = "type"
+	// CodeInvalid is used when a value is not in a valid format.
+	CodeInvalid = "invalid"
+	// CodeLookup is used when validating a value requires a lookup, like a DNS query, and the lookup fails.
+	CodeLookup = "lookup"
+	// CodeNoValidator is used when the validator named in the tag data is not registered.
+	CodeNoValidator = "novalidator"
+	// CodeBadTag is used when the tag data for a field is not valid.
+	CodeBadTag = "badtag"
+)
+
+// Sentinel errors for each failure category. Every FieldError produced by the built in validators wraps the sentinel for its Code, so errors.Is can be used to classify failures.
+var (
+	// ErrRequired is wrapped by errors with the code CodeRequired.
+	ErrRequired = errors.New("required")
+	// ErrExcluded is wrapped by errors with the code CodeExcluded.
+	ErrExcluded = errors.New("excluded")
+	// ErrMin is wrapped by errors with the code CodeMin.
+	ErrMin = errors.New("min")
+	// ErrMax is wrapped by errors with the code CodeMax.
+	ErrMax = errors.New("max")
+	// ErrLen is wrapped by errors with the code CodeLen.
+	ErrLen = errors.New("len")
+	// ErrUnique is wrapped by errors with the code CodeUnique.
+	ErrUnique = errors.New("unique")
+	// ErrFieldComparison is wrapped by errors with the codes CodeEqField, CodeNeField, CodeGtField, CodeGteField, CodeLtField and CodeLteField.
+	ErrFieldComparison = errors.New("field comparison")
+	// ErrType is wrapped by errors with the code CodeType.
+	ErrType = errors.New("type")
+	// ErrInvalidFormat is wrapped by errors with the code CodeInvalid.
+	ErrInvalidFormat = errors.New("invalid format")
+	// ErrLookup is wrapped by errors with the code CodeLookup.
+	ErrLookup = errors.New("lookup failed")
+	// ErrNoValidator is wrapped by errors with the code CodeNoValidator.
+	ErrNoValidator = errors.New("no validator")
+	// ErrBadTag is wrapped by errors with the code CodeBadTag.
+	ErrBadTag = errors.New("bad tag")
+)
+
+var (
+	// codeSentinels maps each rule code to the sentinel error wrapped by a FieldError with that code.
+	codeSentinels = map[string]error{
+		CodeRequired:    ErrRequired,
+		CodeExcluded:    ErrExcluded,
+		CodeMin:         ErrMin,
+		CodeMax:         ErrMax,
+		CodeLen:         ErrLen,
+		CodeUnique:      ErrUnique,
+		CodeEqField:     ErrFieldComparison,
+		CodeNeField:     ErrFieldComparison,
+		CodeGtField:     ErrFieldComparison,
+		CodeGteField:    ErrFieldComparison,
+		CodeLtField:     ErrFieldComparison,
+		CodeLteField:    ErrFieldComparison,
+		CodeType:        ErrType,
+		CodeInvalid:     ErrInvalidFormat,
+		CodeLookup:      ErrLookup,
+		CodeNoValidator: ErrNoValidator,
+		CodeBadTag:      ErrBadTag,
+	}
+)
+
+/*
+FieldError is the error returned when a value fails validation.
+
+It carries enough information about the failure to handle it programmatically, without having to parse the error message.
+*/
+type FieldError struct {
+	// Validator is the name of the validator that produced the error. It is empty when the error was produced by the validation package itself, for instance a required pointer that is nil.
+	Validator string
+	// Code is the rule code for the failure, for instance CodeMin or CodeRequired.
+	Code string
+	// Field is the path to the field that failed validation, for instance "Detail.Name" or "Arry[2]".
+	Field string
+	// Param is the value of the tag parameter for the rule that failed, for instance "3" for min=3. It is empty when the rule has no parameter.
+	Param string
+	// Value is the value that failed validation.
+	Value interface{}
+	// Key is the catalog key for the message template of the failure, used when a validator has more than one message for the same Code. It is optional.
+	Key string
+	// Details contains extra named parameters for the message template, for instance "length" for the string validator.
+	Details map[string]string
+	// Message, when set, is used as the description of the failure instead of a catalog template.
+	Message string
+}
+
+// NewFieldError creates a FieldError with the provided information.
+// When message is empty the description of the failure is rendered from the catalog for the locale, see Catalog for more information.
+func NewFieldError(validatorName, code, fieldName, param string, value interface{}, message string) *FieldError {
+	return &FieldError{
+		Validator: validatorName,
+		Code:      code,
+		Field:     fieldName,
+		Param:     param,
+		Value:     value,
+		Message:   message,
+	}
+}
+
+// WithKey sets the catalog key for the message template of the failure and returns the FieldError.
+func (fe *FieldError) WithKey(key string) *FieldError {
+	fe.Key = key
+	return fe
+}
+
+// WithDetail adds a named parameter for the message template and returns the FieldError.
+func (fe *FieldError) WithDetail(name, value string) *FieldError {
+	if fe.Details == nil {
+		fe.Details = map[string]string{}
+	}
+	fe.Details[name] = value
+	return fe
+}
+
+// Params returns the named parameters for the message template of the failure, see Catalog for the list of parameters.
+func (fe *FieldError) Params() map[string]string {
+	params := make(map[string]string, len(fe.Details)+5)
+	for name, value := range fe.Details {
+		params[name] = value
+	}
+	params["field"] = fe.Field
+	params["param"] = fe.Param
+	params["value"] = formatValue(fe.Value)
+	params["validator"] = fe.Validator
+	params["code"] = fe.Code
+	if _, ok := params["type"]; !ok {
+		params["type"] = fmt.Sprintf("%T", fe.Value)
+	}
+	return params
+}
+
+// templateKeys returns the catalog keys for the message template of the failure, in the order they are tried.
+func (fe *FieldError) templateKeys() []string {
+	keys := make([]string, 0, 3)
+	if fe.Key != "" {
+		keys = append(keys, fe.Key)
+	}
+	if fe.Validator != "" {
+		keys = append(keys, fe.Validator+"."+fe.Code)
+	}
+	return append(keys, fe.Code)
+}
+
+// Localize returns the description of the failure rendered from the catalog for the locale. When Message is set it is returned instead.
+func (fe *FieldError) Localize(locale string) string {
+	catalog, _ := CatalogFor(locale)
+	return fe.LocalizeCatalog(catalog)
+}
+
+// LocalizeCatalog returns the description of the failure rendered from the catalog, see LookupCatalogTemplate. When Message is set it is returned instead.
+func (fe *FieldError) LocalizeCatalog(catalog Catalog) string {
+	if fe.Message != "" {
+		return fe.Message
+	}
+	template, ok := LookupCatalogTemplate(catalog, fe.templateKeys()...)
+	if !ok {
+		template = "{code}: the field {field} is not valid"
+	}
+	return RenderTemplate(template, fe.Params())
+}
+
+// Error returns the description of the failure in the DefaultLocale.
+func (fe *FieldError) Error() string {
+	return fe.Localize(DefaultLocale)
+}
+
+// Unwrap returns the sentinel error for the FieldError Code, so errors.Is(err, ErrMin) is true for a FieldError with the code CodeMin.
+// It returns nil when the Code has no sentinel, for instance a code used by a custom validator.
+func (fe *FieldError) Unwrap() error {
+	return codeSentinels[fe.Code]
+}
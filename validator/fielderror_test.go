@@ -0,0 +1,32 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFieldErrorUnwrap(t *testing.T) {
+	sentinels := map[string]error{
+		CodeRequired:    ErrRequired,
+		CodeMin:         ErrMin,
+		CodeMax:         ErrMax,
+		CodeType:        ErrType,
+		CodeInvalid:     ErrInvalidFormat,
+		CodeLookup:      ErrLookup,
+		CodeNoValidator: ErrNoValidator,
+		CodeBadTag:      ErrBadTag,
+	}
+	for code, sentinel := range sentinels {
+		err := NewFieldError("test", code, "testValue", "", nil, "test message")
+		if !errors.Is(err, sentinel) {
+			t.Errorf("a FieldError with code %s should wrap %v", code, sentinel)
+		}
+	}
+}
+
+func TestFieldErrorUnwrapUnknownCode(t *testing.T) {
+	err := NewFieldError("test", "custom", "testValue", "", nil, "test message")
+	if errors.Unwrap(err) != nil {
+		t.Error("a FieldError with a custom code should not wrap a sentinel")
+	}
+}
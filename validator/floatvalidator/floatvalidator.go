@@ -5,14 +5,13 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
 
 	"github.com/calvine/simplevalidation/validator"
 )
 
 const (
-	numberMinValueErrorTemplate = "min: the field %s value %f is less than the minimum value %f"
-	numberMaxValueErrorTemplate = "max: the field %s value %f is greater than the maximum value %f"
+	// validatorName is the name the float validator is registered under in the validation package.
+	validatorName = "float"
 )
 
 type floatValidator struct {
@@ -28,14 +27,12 @@ func New() validator.Validator {
 func validateFloat(i float64, min, max *float64, name string) (bool, error) {
 	if min != nil {
 		if i < *min {
-			errorMessage := fmt.Sprintf(numberMinValueErrorTemplate, name, i, *min)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeMin, name, strconv.FormatFloat(*min, 'f', -1, 64), i, "")
 		}
 	}
 	if max != nil {
 		if i > *max {
-			errorMessage := fmt.Sprintf(numberMaxValueErrorTemplate, name, i, *max)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeMax, name, strconv.FormatFloat(*max, 'f', -1, 64), i, "")
 		}
 	}
 	return true, nil
@@ -50,8 +47,7 @@ func (nv *floatValidator) Validate(n interface{}, fieldName string, fieldKind re
 		var ok bool
 		value, ok = n.(float64)
 		if !ok {
-			errorMessage := fmt.Sprintf(validator.InvalidTypeErrorTemplate, fieldName, t)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeType, fieldName, "", n, "")
 		}
 	}
 	return validateFloat(value, nv.Min, nv.Max, fieldName)
@@ -59,16 +55,20 @@ func (nv *floatValidator) Validate(n interface{}, fieldName string, fieldKind re
 
 func (nv *floatValidator) ReadOptionsFromTagItems(items []string) error {
 	for i := 0; i < len(items); i++ {
-		switch parts := strings.Split(items[i], "="); parts[0] {
+		option, err := validator.ParseOption(items[i])
+		if err != nil {
+			return err
+		}
+		switch option.Key {
 		case "min":
-			min, err := strconv.ParseFloat(parts[1], 64)
+			min, err := strconv.ParseFloat(option.Value, 64)
 			if err != nil {
 				errorString := fmt.Sprintf("floatValidator tag min value invalid: %s", err.Error())
 				return errors.New(errorString)
 			}
 			nv.Min = &min
 		case "max":
-			max, err := strconv.ParseFloat(parts[1], 64)
+			max, err := strconv.ParseFloat(option.Value, 64)
 			if err != nil {
 				errorString := fmt.Sprintf("floatValidator tag max value invalid: %s", err.Error())
 				return errors.New(errorString)
@@ -78,3 +78,16 @@ func (nv *floatValidator) ReadOptionsFromTagItems(items []string) error {
 	}
 	return nil
 }
+
+// Options returns the options the float validator accepts in tag data.
+func (nv *floatValidator) Options() []validator.OptionSpec {
+	return []validator.OptionSpec{
+		{Key: "min", Description: "the minimum value allowed"},
+		{Key: "max", Description: "the maximum value allowed"},
+	}
+}
+
+// CheckType returns an error unless the type is float32 or float64.
+func (nv *floatValidator) CheckType(t reflect.Type) error {
+	return validator.CheckTypeIs(validatorName, t, reflect.TypeOf(float32(0)), reflect.TypeOf(float64(0)))
+}
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
 )
 
 func TestValidIntValue(t *testing.T) {
@@ -200,3 +202,20 @@ func TestReadOptionsFromTagItemsAllParameters(t *testing.T) {
 		t.Error("*Max should be 7")
 	}
 }
+
+func TestInvalidMaxFieldError(t *testing.T) {
+	max := int64(3)
+	tValidator := intValidator{
+		Max: &max,
+	}
+	testValue := int64(4)
+	valueKind := reflect.TypeOf(testValue).Kind()
+	_, err := tValidator.Validate(testValue, "testValue", valueKind)
+	fieldError, ok := err.(*validator.FieldError)
+	if !ok {
+		t.Fatalf("err should be a *validator.FieldError: %T", err)
+	}
+	if fieldError.Validator != "int" || fieldError.Code != validator.CodeMax || fieldError.Param != "3" || fieldError.Value != testValue {
+		t.Errorf("fieldError does not have the expected values: %+v", fieldError)
+	}
+}
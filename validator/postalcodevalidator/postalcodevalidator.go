@@ -1,23 +1,18 @@
 package postalcodevalidator
 
 import (
-	"errors"
-	"fmt"
 	"reflect"
 	"regexp"
-	"strings"
 
 	"github.com/calvine/simplevalidation/validator"
 )
 
 // TODO: check a collection ov valid zip codes or an external service?
-type postalcodeValidator struct {
-	Required bool
-}
+type postalcodeValidator struct{}
 
 const (
-	postalcodeInvalidErrorTemplate  = "invalid: the field %s does cont contain a valid email. '%s' was provided"
-	postalcodeRequiredErrorTemplate = "required: the field %s is required"
+	// validatorName is the name the postal code validator is registered under in the validation package.
+	validatorName = "postalcode"
 )
 
 var (
@@ -32,30 +27,26 @@ func New() validator.Validator {
 func (pcv *postalcodeValidator) Validate(n interface{}, fieldName string, fieldKind reflect.Kind) (bool, error) {
 	value, ok := n.(string)
 	if !ok {
-		errorMessage := fmt.Sprintf(validator.InvalidTypeErrorTemplate, fieldName, n)
-		return false, errors.New(errorMessage)
-	}
-	valueProvided := value != ""
-
-	if pcv.Required && !valueProvided {
-		errorMessage := fmt.Sprintf(postalcodeRequiredErrorTemplate, fieldName)
-		return false, errors.New(errorMessage)
+		return false, validator.NewFieldError(validatorName, validator.CodeType, fieldName, "", n, "")
 	}
-	if valueProvided {
-		if !postalCodeValidationRegexp.Match([]byte(value)) {
-			errorMessage := fmt.Sprintf(postalcodeInvalidErrorTemplate, fieldName, value)
-			return false, errors.New(errorMessage)
-		}
+	// an empty string is not a valid postal code, use the omitempty option to allow it.
+	if !postalCodeValidationRegexp.Match([]byte(value)) {
+		return false, validator.NewFieldError(validatorName, validator.CodeInvalid, fieldName, "", value, "")
 	}
 	return true, nil
 }
 
+// ReadOptionsFromTagItems reads nothing, since the postal code validator has no options. The required option is checked by the validation package.
 func (pcv *postalcodeValidator) ReadOptionsFromTagItems(items []string) error {
-	for i := 0; i < len(items); i++ {
-		switch parts := strings.Split(items[i], "="); parts[0] {
-		case "required":
-			pcv.Required = true
-		}
-	}
 	return nil
 }
+
+// Options returns the options the postal code validator accepts in tag data, it has none besides the options read by the validation package.
+func (pcv *postalcodeValidator) Options() []validator.OptionSpec {
+	return nil
+}
+
+// CheckType returns an error unless the type is string.
+func (pcv *postalcodeValidator) CheckType(t reflect.Type) error {
+	return validator.CheckTypeIs(validatorName, t, reflect.TypeOf(""))
+}
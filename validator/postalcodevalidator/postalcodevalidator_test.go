@@ -1,15 +1,16 @@
 package postalcodevalidator
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
 )
 
 func TestValidPostalCode(t *testing.T) {
-	tValidator := postalcodeValidator{
-		Required: true,
-	}
+	tValidator := postalcodeValidator{}
 	testValue := "31008"
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
@@ -18,32 +19,15 @@ func TestValidPostalCode(t *testing.T) {
 	}
 }
 
-func TestValidNotRequiredEmptyPostalCode(t *testing.T) {
-	tValidator := postalcodeValidator{
-		Required: false,
-	}
-	testValue := ""
-	valueKind := reflect.TypeOf(testValue).Kind()
-	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
-	if !isValid {
-		t.Error("isValid should be true because Required is false and an empty string was provided: ", err.Error())
-	}
-}
-
-func TestInvalidRequired(t *testing.T) {
-	tValidator := postalcodeValidator{
-		Required: true,
-	}
+func TestInvalidEmptyPostalCode(t *testing.T) {
+	tValidator := postalcodeValidator{}
 	testValue := ""
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
-	expectedErrorPrefix := "required:"
 	if isValid {
-		t.Error("isValid should be false because Required is true and the value provided is an empty string")
-	} else if err == nil {
-		t.Error("err should be populated")
-	} else if hasRightIndex := strings.Index(err.Error(), expectedErrorPrefix); hasRightIndex == -1 {
-		t.Errorf("err.Error() begin with '%s': %s", expectedErrorPrefix, err.Error())
+		t.Error("isValid should be false because an empty string is not a valid postal code, the omitempty option allows it instead")
+	} else if !errors.Is(err, validator.ErrInvalidFormat) {
+		t.Errorf("err should wrap validator.ErrInvalidFormat: %v", err)
 	}
 }
 
@@ -63,9 +47,7 @@ func TestInvalidPostalCode(t *testing.T) {
 }
 
 func TestInvalidType(t *testing.T) {
-	tValidator := postalcodeValidator{
-		Required: true,
-	}
+	tValidator := postalcodeValidator{}
 	testValue := uint(32)
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
@@ -84,8 +66,7 @@ func TestReadOptionsFromTagItemsAllParameters(t *testing.T) {
 	testTag := "postalcode,required"
 	tagArgs := strings.Split(testTag, ",")
 	tValidator := postalcodeValidator{}
-	tValidator.ReadOptionsFromTagItems(tagArgs[1:])
-	if tValidator.Required != true {
-		t.Error("Required should be true")
+	if err := tValidator.ReadOptionsFromTagItems(tagArgs[1:]); err != nil {
+		t.Errorf("the postal code validator has no options to read, so err should be nil: %s", err.Error())
 	}
 }
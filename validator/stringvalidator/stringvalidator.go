@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
 
 	"github.com/calvine/simplevalidation/validator"
 )
@@ -13,23 +12,17 @@ import (
 // Struct that contains the fields required to validate a string.
 type stringValidator struct {
 	// The min length allowed for the input string.
-	// One note this this implementation is that if if have a min value set, and you provide no value,
-	// we technically say that is valid, because not providing a value is covered under
-	// the required option case.
-	// Alternatively, if you have a min set to 3 and procive a string with a length of 2
-	// that will fail validation.
+	// An empty string is shorter than any min greater than 0, so it fails validation.
+	// To allow an empty string use the omitempty option, which is checked by the validation package.
 	Min *int
 	// The max length allowed for the input string.
 	Max *int
-	// If true then an empty string ("") will cause a validation error.
-	Required bool
 	//pattern string
 }
 
 const (
-	stringMinLengthTemplate = "min length: the value of %s is %s of length %d which is less than the minimum length %d"
-	stringMaxLengthTemplate = "max length: the value of %s is %s of length %d which is greater than the maximum length %d"
-	stringRequiredTemplate  = "required: the value of %s is blank"
+	// validatorName is the name the string validator is registered under in the validation package.
+	validatorName = "string"
 )
 
 func New() validator.Validator {
@@ -40,51 +33,58 @@ func New() validator.Validator {
 func (nv *stringValidator) Validate(n interface{}, fieldName string, fieldKind reflect.Kind) (bool, error) {
 	stringValue, ok := n.(string)
 	if !ok {
-		errorMessage := fmt.Sprintf(validator.InvalidTypeErrorTemplate, fieldName, n)
-		return false, errors.New(errorMessage)
+		return false, validator.NewFieldError(validatorName, validator.CodeType, fieldName, "", n, "")
 	}
 	valueLength := len(stringValue)
-	noValueProvided := valueLength == 0
 	if nv.Min != nil {
-		if (!noValueProvided || *nv.Min == 0) && valueLength < *nv.Min {
-			errorMessage := fmt.Sprintf(stringMinLengthTemplate, fieldName, stringValue, valueLength, *nv.Min)
-			return false, errors.New(errorMessage)
+		if valueLength < *nv.Min {
+			return false, validator.NewFieldError(validatorName, validator.CodeMin, fieldName, strconv.Itoa(*nv.Min), stringValue, "").WithDetail("length", strconv.Itoa(valueLength))
 		}
 	}
 	if nv.Max != nil {
 		if valueLength > *nv.Max {
-			errorMessage := fmt.Sprintf(stringMaxLengthTemplate, fieldName, stringValue, valueLength, *nv.Max)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeMax, fieldName, strconv.Itoa(*nv.Max), stringValue, "").WithDetail("length", strconv.Itoa(valueLength))
 		}
 	}
-	if nv.Required && noValueProvided {
-		errorMessage := fmt.Sprintf(stringRequiredTemplate, fieldName)
-		return false, errors.New(errorMessage)
-	}
 	return true, nil
 
 }
 
 func (nv *stringValidator) ReadOptionsFromTagItems(items []string) error {
 	for i := 0; i < len(items); i++ {
-		switch parts := strings.Split(items[i], "="); parts[0] {
+		option, err := validator.ParseOption(items[i])
+		if err != nil {
+			return err
+		}
+		switch option.Key {
 		case "min":
-			min, err := strconv.Atoi(parts[1])
+			min, err := strconv.Atoi(option.Value)
 			if err != nil {
 				errorString := fmt.Sprintf("stringValidator tag min value invalid: %s", err.Error())
 				return errors.New(errorString)
 			}
 			nv.Min = &min
 		case "max":
-			max, err := strconv.Atoi(parts[1])
+			max, err := strconv.Atoi(option.Value)
 			if err != nil {
 				errorString := fmt.Sprintf("stringValidator tag max value invalid: %s", err.Error())
 				return errors.New(errorString)
 			}
 			nv.Max = &max
-		case "required":
-			nv.Required = true
 		}
 	}
 	return nil
 }
+
+// Options returns the options the string validator accepts in tag data.
+func (nv *stringValidator) Options() []validator.OptionSpec {
+	return []validator.OptionSpec{
+		{Key: "min", Description: "the minimum length allowed"},
+		{Key: "max", Description: "the maximum length allowed"},
+	}
+}
+
+// CheckType returns an error unless the type is string.
+func (nv *stringValidator) CheckType(t reflect.Type) error {
+	return validator.CheckTypeIs(validatorName, t, reflect.TypeOf(""))
+}
@@ -1,17 +1,19 @@
 package stringvalidator
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/calvine/simplevalidation/validator"
 )
 
 func TestValidString(t *testing.T) {
 	min, max := 8, 16
 	tValidator := stringValidator{
-		Min:      &min,
-		Max:      &max,
-		Required: true,
+		Min: &min,
+		Max: &max,
 	}
 	testValue := "this is a string"
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -24,8 +26,7 @@ func TestValidString(t *testing.T) {
 func TestValidMinLength(t *testing.T) {
 	min := 3
 	tValidator := stringValidator{
-		Min:      &min,
-		Required: true,
+		Min: &min,
 	}
 	testValue := "hello"
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -38,8 +39,7 @@ func TestValidMinLength(t *testing.T) {
 func TestValidMaxLength(t *testing.T) {
 	max := 8
 	tValidator := stringValidator{
-		Max:      &max,
-		Required: true,
+		Max: &max,
 	}
 	testValue := "hello"
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -52,8 +52,7 @@ func TestValidMaxLength(t *testing.T) {
 func TestValidMinLengthInclusive(t *testing.T) {
 	min := 3
 	tValidator := stringValidator{
-		Min:      &min,
-		Required: true,
+		Min: &min,
 	}
 	testValue := "hey"
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -66,8 +65,7 @@ func TestValidMinLengthInclusive(t *testing.T) {
 func TestValidMaxLengthInclusive(t *testing.T) {
 	max := 5
 	tValidator := stringValidator{
-		Max:      &max,
-		Required: true,
+		Max: &max,
 	}
 	testValue := "hello"
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -78,39 +76,34 @@ func TestValidMaxLengthInclusive(t *testing.T) {
 }
 
 func TestValidNotRequiredEmptyString(t *testing.T) {
-	tValidator := stringValidator{
-		Required: false,
-	}
+	tValidator := stringValidator{}
 	testValue := ""
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
 	if !isValid {
-		t.Error("isValid should be true because Required is false, and an empty string is provided: ", err.Error())
+		t.Error("isValid should be true because an empty string is provided: ", err.Error())
 	}
 }
 
-func TestInvalidRequired(t *testing.T) {
+func TestInvalidMinEmptyString(t *testing.T) {
+	min := 1
 	tValidator := stringValidator{
-		Required: true,
+		Min: &min,
 	}
 	testValue := ""
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
-	expectedErrorPrefix := "required:"
 	if isValid {
-		t.Error("isValid should be false because Required is true and the value provided is an empty string")
-	} else if err == nil {
-		t.Error("err should be populated")
-	} else if hasRightIndex := strings.Index(err.Error(), expectedErrorPrefix); hasRightIndex == -1 {
-		t.Errorf("err.Error() begin with '%s': %s", expectedErrorPrefix, err.Error())
+		t.Error("isValid should be false because an empty string is shorter than min, the omitempty option allows it instead")
+	} else if !errors.Is(err, validator.ErrMin) {
+		t.Errorf("err should wrap validator.ErrMin: %v", err)
 	}
 }
 
 func TestInvalidMin(t *testing.T) {
 	min := 5
 	tValidator := stringValidator{
-		Min:      &min,
-		Required: true,
+		Min: &min,
 	}
 	testValue := "test"
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -128,8 +121,7 @@ func TestInvalidMin(t *testing.T) {
 func TestInvalidMax(t *testing.T) {
 	max := 5
 	tValidator := stringValidator{
-		Max:      &max,
-		Required: true,
+		Max: &max,
 	}
 	testValue := "testing"
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -145,9 +137,7 @@ func TestInvalidMax(t *testing.T) {
 }
 
 func TestInvalidType(t *testing.T) {
-	tValidator := stringValidator{
-		Required: true,
-	}
+	tValidator := stringValidator{}
 	testValue := uint(32)
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
@@ -167,9 +157,6 @@ func TestReadOptionsFromTagItemsAllParameters(t *testing.T) {
 	tagArgs := strings.Split(testTag, ",")
 	tValidator := stringValidator{}
 	tValidator.ReadOptionsFromTagItems(tagArgs[1:])
-	if tValidator.Required != true {
-		t.Error("Required should be true")
-	}
 	if tValidator.Min == nil || *tValidator.Min != 3 {
 		t.Error("*Min should be 3")
 	}
@@ -177,3 +164,20 @@ func TestReadOptionsFromTagItemsAllParameters(t *testing.T) {
 		t.Error("*Max should be 8")
 	}
 }
+
+func TestInvalidMinFieldError(t *testing.T) {
+	min := 5
+	tValidator := stringValidator{
+		Min: &min,
+	}
+	testValue := "test"
+	valueKind := reflect.TypeOf(testValue).Kind()
+	_, err := tValidator.Validate(testValue, "testValue", valueKind)
+	fieldError, ok := err.(*validator.FieldError)
+	if !ok {
+		t.Fatalf("err should be a *validator.FieldError: %T", err)
+	}
+	if fieldError.Validator != "string" || fieldError.Code != validator.CodeMin || fieldError.Field != "testValue" || fieldError.Param != "5" || fieldError.Value != testValue {
+		t.Errorf("fieldError does not have the expected values: %+v", fieldError)
+	}
+}
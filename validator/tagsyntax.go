@@ -0,0 +1,257 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// tagQuote quotes a value in tag data, so it can contain separators like commas and equal signs.
+	tagQuote = '\''
+	// tagEscape escapes the next character in tag data, inside or outside of quotes.
+	tagEscape = '\\'
+	// listStart and listEnd surround a list value in tag data, for instance [red green 'dark blue'].
+	listStart = '['
+	listEnd   = ']'
+
+	unterminatedQuoteMessage = "unterminated quote"
+	unterminatedListMessage  = "unterminated list"
+	unexpectedListEndMessage = "unexpected ]"
+	trailingEscapeMessage    = "escape at the end of the tag data"
+	notAListMessage          = "the value is not a list"
+)
+
+/*
+	TagSyntaxError is returned when tag data cannot be parsed, for instance because a quote is not terminated.
+
+	It wraps ErrBadTag, so errors.Is(err, ErrBadTag) is true for a TagSyntaxError.
+*/
+type TagSyntaxError struct {
+	// Field is the name of the field with the tag data. It is empty when the field is not known.
+	Field string
+	// Column is the 1 based position of the syntax error in the tag data.
+	Column int
+	// Message describes the syntax error.
+	Message string
+}
+
+// Error returns a description of the syntax error, including the field and the column.
+func (tse *TagSyntaxError) Error() string {
+	if tse.Field != "" {
+		return fmt.Sprintf("syntax error in the tag data of %s at column %d: %s", tse.Field, tse.Column, tse.Message)
+	}
+	return fmt.Sprintf("syntax error in the tag data at column %d: %s", tse.Column, tse.Message)
+}
+
+// Unwrap returns ErrBadTag.
+func (tse *TagSyntaxError) Unwrap() error {
+	return ErrBadTag
+}
+
+// TagItem is an item of tag data along with its position in the tag data.
+type TagItem struct {
+	// Text is the text of the item, with its quotes and escapes.
+	Text string
+	// Column is the 1 based position of the start of the item in the tag data.
+	Column int
+}
+
+/*
+	SplitTagItems splits tag data into items at each separator that is not quoted, escaped or in a list.
+
+	Values are quoted with single quotes and any character can be escaped with a backslash, so these tag data each have three items when split at commas:
+
+		string,min=3,msg='Please, enter a name'
+		string,min=3,msg=Please\, enter a name
+		enum,required,values=[red green 'dark blue']
+
+	The items keep their quotes and escapes, use ParseOption to read them.
+*/
+func SplitTagItems(tag string, separator rune) ([]TagItem, error) {
+	items := []TagItem{}
+	start := 0
+	quoteColumn := 0
+	listColumns := []int{}
+	escaped := false
+	for i, r := range tag {
+		switch {
+		case escaped:
+			escaped = false
+		case r == tagEscape:
+			escaped = true
+		case r == tagQuote:
+			if quoteColumn == 0 {
+				quoteColumn = i + 1
+			} else {
+				quoteColumn = 0
+			}
+		case quoteColumn != 0:
+			// every other character is part of the quoted value.
+		case r == listStart:
+			listColumns = append(listColumns, i+1)
+		case r == listEnd:
+			if len(listColumns) == 0 {
+				return nil, &TagSyntaxError{Column: i + 1, Message: unexpectedListEndMessage}
+			}
+			listColumns = listColumns[:len(listColumns)-1]
+		case r == separator && len(listColumns) == 0:
+			items = append(items, TagItem{Text: tag[start:i], Column: start + 1})
+			start = i + 1
+		}
+	}
+	switch {
+	case escaped:
+		return nil, &TagSyntaxError{Column: len(tag), Message: trailingEscapeMessage}
+	case quoteColumn != 0:
+		return nil, &TagSyntaxError{Column: quoteColumn, Message: unterminatedQuoteMessage}
+	case len(listColumns) > 0:
+		return nil, &TagSyntaxError{Column: listColumns[0], Message: unterminatedListMessage}
+	}
+	return append(items, TagItem{Text: tag[start:], Column: start + 1}), nil
+}
+
+// Option is a validator option read from a tag item with ParseOption.
+type Option struct {
+	// Key is the text before the first equal sign that is not quoted or escaped, for instance "min" for min=3.
+	Key string
+	// Value is the text after the equal sign with its quotes and escapes removed.
+	Value string
+	// Raw is the text after the equal sign with its quotes and escapes, used to read lists and fields.
+	Raw string
+	// HasValue is true when the item has an equal sign, even if the value is empty.
+	HasValue bool
+}
+
+/*
+	ParseOption reads a validator option from a tag item in the format key or key=value.
+
+	The value may be quoted with single quotes and may contain escaped characters, so msg='a, b' has the value "a, b" and pattern=a\=b has the value "a=b".
+	Validators should read their options with ParseOption instead of splitting the items themselves.
+*/
+func ParseOption(item string) (Option, error) {
+	option := Option{}
+	separatorIndex := indexUnquoted(item, '=')
+	if separatorIndex < 0 {
+		key, err := unquote(item, 0)
+		option.Key = key
+		return option, err
+	}
+	key, err := unquote(item[:separatorIndex], 0)
+	if err != nil {
+		return option, err
+	}
+	option.Key = key
+	option.HasValue = true
+	option.Raw = item[separatorIndex+1:]
+	option.Value, err = unquote(option.Raw, separatorIndex+1)
+	return option, err
+}
+
+// IsList returns true when the value is a list surrounded by square brackets.
+func (o Option) IsList() bool {
+	raw := strings.TrimSpace(o.Raw)
+	return len(raw) >= 2 && raw[0] == listStart && raw[len(raw)-1] == listEnd
+}
+
+// List returns the values of a list value like [red green 'dark blue'], which are separated by spaces.
+func (o Option) List() ([]string, error) {
+	if !o.IsList() {
+		return nil, &TagSyntaxError{Column: 1, Message: notAListMessage}
+	}
+	raw := strings.TrimSpace(o.Raw)
+	return SplitFields(raw[1 : len(raw)-1])
+}
+
+// Fields returns the values in the value separated by spaces, for instance "Country US" has the fields "Country" and "US". Quoted values can contain spaces.
+func (o Option) Fields() ([]string, error) {
+	return SplitFields(o.Raw)
+}
+
+// SplitFields splits the text at each space that is not quoted or escaped, and removes the quotes and escapes from each field.
+func SplitFields(text string) ([]string, error) {
+	fields := []string{}
+	start := -1
+	inQuote := false
+	escaped := false
+	for i, r := range text + " " {
+		switch {
+		case escaped:
+			escaped = false
+		case r == tagEscape:
+			escaped = true
+		case r == tagQuote:
+			inQuote = !inQuote
+		case unicode.IsSpace(r) && !inQuote:
+			if start >= 0 {
+				field, err := unquote(text[start:i], start)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, field)
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if inQuote {
+		return nil, &TagSyntaxError{Column: len(text), Message: unterminatedQuoteMessage}
+	}
+	return fields, nil
+}
+
+// indexUnquoted returns the index of the first occurrence of the character that is not quoted or escaped, or -1 if there is none.
+func indexUnquoted(text string, target rune) int {
+	inQuote := false
+	escaped := false
+	for i, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case r == tagEscape:
+			escaped = true
+		case r == tagQuote:
+			inQuote = !inQuote
+		case r == target && !inQuote:
+			return i
+		}
+	}
+	return -1
+}
+
+// unquote removes the quotes and escapes from the text. The offset is the position of the text in the tag item, used for the column of a syntax error.
+func unquote(text string, offset int) (string, error) {
+	if !strings.ContainsAny(text, "'\\") {
+		return text, nil
+	}
+	var builder strings.Builder
+	quoteColumn := 0
+	escaped := false
+	for i, r := range text {
+		switch {
+		case escaped:
+			builder.WriteRune(r)
+			escaped = false
+		case r == tagEscape:
+			escaped = true
+		case r == tagQuote:
+			if quoteColumn == 0 {
+				quoteColumn = offset + i + 1
+			} else {
+				quoteColumn = 0
+			}
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	switch {
+	case escaped:
+		return "", &TagSyntaxError{Column: offset + len(text), Message: trailingEscapeMessage}
+	case quoteColumn != 0:
+		return "", &TagSyntaxError{Column: quoteColumn, Message: unterminatedQuoteMessage}
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSplitTagItems(t *testing.T) {
+	tests := []struct {
+		name            string
+		tag             string
+		expectedTexts   []string
+		expectedColumns []int
+	}{
+		{"plain items", "string,min=3,required", []string{"string", "min=3", "required"}, []int{1, 8, 14}},
+		{"quoted comma", "string,msg='a, b',min=3", []string{"string", "msg='a, b'", "min=3"}, []int{1, 8, 19}},
+		{"escaped comma", `string,msg=a\, b`, []string{"string", `msg=a\, b`}, []int{1, 8}},
+		{"list", "enum,values=[a,b c],required", []string{"enum", "values=[a,b c]", "required"}, []int{1, 6, 21}},
+		{"slice validator name", "[][]int,min=1", []string{"[][]int", "min=1"}, []int{1, 9}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items, err := SplitTagItems(test.tag, ',')
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			texts := []string{}
+			columns := []int{}
+			for _, item := range items {
+				texts = append(texts, item.Text)
+				columns = append(columns, item.Column)
+			}
+			if !reflect.DeepEqual(texts, test.expectedTexts) {
+				t.Errorf("the items should be %q but were %q", test.expectedTexts, texts)
+			}
+			if !reflect.DeepEqual(columns, test.expectedColumns) {
+				t.Errorf("the columns should be %v but were %v", test.expectedColumns, columns)
+			}
+		})
+	}
+}
+
+func TestSplitTagItemsSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		tag            string
+		expectedColumn int
+		expectedMsg    string
+	}{
+		{"unterminated quote", "string,msg='a, b", 12, unterminatedQuoteMessage},
+		{"unterminated list", "enum,values=[a b", 13, unterminatedListMessage},
+		{"unexpected list end", "enum,values=a]", 14, unexpectedListEndMessage},
+		{"trailing escape", `string,msg=a\`, 13, trailingEscapeMessage},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := SplitTagItems(test.tag, ',')
+			syntaxErr := &TagSyntaxError{}
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("err should be a *TagSyntaxError: %v", err)
+			}
+			if syntaxErr.Column != test.expectedColumn || syntaxErr.Message != test.expectedMsg {
+				t.Errorf("the syntax error should be at column %d with message %q: %+v", test.expectedColumn, test.expectedMsg, syntaxErr)
+			}
+			if !errors.Is(err, ErrBadTag) {
+				t.Error("a TagSyntaxError should wrap ErrBadTag")
+			}
+		})
+	}
+}
+
+func TestParseOption(t *testing.T) {
+	tests := []struct {
+		item     string
+		expected Option
+	}{
+		{"required", Option{Key: "required"}},
+		{"min=3", Option{Key: "min", Value: "3", Raw: "3", HasValue: true}},
+		{"min=", Option{Key: "min", HasValue: true}},
+		{"msg='a, b=c'", Option{Key: "msg", Value: "a, b=c", Raw: "'a, b=c'", HasValue: true}},
+		{`msg='it\'s'`, Option{Key: "msg", Value: "it's", Raw: `'it\'s'`, HasValue: true}},
+		{`pattern=a\=b`, Option{Key: "pattern", Value: "a=b", Raw: `a\=b`, HasValue: true}},
+	}
+	for _, test := range tests {
+		option, err := ParseOption(test.item)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.item, err)
+			continue
+		}
+		if option != test.expected {
+			t.Errorf("%s: the option should be %+v but was %+v", test.item, test.expected, option)
+		}
+	}
+}
+
+func TestParseOptionSyntaxError(t *testing.T) {
+	_, err := ParseOption("msg='abc")
+	syntaxErr := &TagSyntaxError{}
+	if !errors.As(err, &syntaxErr) || syntaxErr.Column != 5 {
+		t.Errorf("err should be a *TagSyntaxError at column 5: %v", err)
+	}
+}
+
+func TestOptionListAndFields(t *testing.T) {
+	option, _ := ParseOption("values=[red green 'dark blue']")
+	list, err := option.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"red", "green", "dark blue"}; !reflect.DeepEqual(list, expected) {
+		t.Errorf("the list should be %q but was %q", expected, list)
+	}
+	option, _ = ParseOption("required_if=City 'New York' Country US")
+	fields, err := option.Fields()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"City", "New York", "Country", "US"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("the fields should be %q but were %q", expected, fields)
+	}
+	if _, err := option.List(); !errors.Is(err, ErrBadTag) {
+		t.Error("List should return an error when the value is not a list")
+	}
+}
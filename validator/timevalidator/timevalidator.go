@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/calvine/simplevalidation/validator"
@@ -19,15 +18,13 @@ type timeValidator struct {
 	Nbf *int64
 	// Nbf stands for Not After and must be a unix timestamp that can be cast as an int64.
 	Naf *int64
-	// Required only checks for the default value of a time struct. If an int64 is used as input this parameter is ignored.
-	Required bool
 }
 
 const (
-	timeIntNotAllowedErrorTemplate = "type: the field %s is an int, but AllowInt was false"
-	timeNotBeforeErrorTemplate     = "not before: the field %s has a value of '%s' which is before '%s'"
-	timeNotAfterErrorTemplate      = "not after: the field %s has a value of '%s' which is after '%s'"
-	timeNoValueErrorTemplate       = "required: The field %s had an empty value: %v"
+	// validatorName is the name the time validator is registered under in the validation package.
+	validatorName = "time"
+	// allowIntKey is the catalog key for the error when an int64 is provided but allowint is not set.
+	allowIntKey = "time.allowint"
 )
 
 func New() validator.Validator {
@@ -37,33 +34,23 @@ func New() validator.Validator {
 
 func (tv *timeValidator) Validate(n interface{}, fieldName string, fieldKind reflect.Kind) (bool, error) {
 	var value int64
-	noValueProvided := false
 	switch t := n.(type) {
 	case time.Time:
 		value = t.Unix()
-		noValueProvided = t == time.Time{}
 	case int64:
 		if !tv.AllowInt {
-			errorMessage := fmt.Sprintf(timeIntNotAllowedErrorTemplate, fieldName)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeType, fieldName, "", n, "").WithKey(allowIntKey)
 		}
 		value = t
 	default:
-		errorMessage := fmt.Sprintf(validator.InvalidTypeErrorTemplate, fieldName, t)
-		return false, errors.New(errorMessage)
-	}
-
-	if tv.Required && noValueProvided {
-		errorMessage := fmt.Sprintf(timeNoValueErrorTemplate, fieldName, value)
-		return false, errors.New(errorMessage)
+		return false, validator.NewFieldError(validatorName, validator.CodeType, fieldName, "", n, "")
 	}
 
 	if tv.Nbf != nil {
 		if value < *tv.Nbf {
 			nbfString := time.Unix(*tv.Nbf, 0).UTC().String()
 			valueString := time.Unix(value, 0).UTC().String()
-			errorMessage := fmt.Sprintf(timeNotBeforeErrorTemplate, fieldName, valueString, nbfString)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeMin, fieldName, strconv.FormatInt(*tv.Nbf, 10), n, "").WithDetail("time", valueString).WithDetail("limit", nbfString)
 		}
 	}
 
@@ -71,8 +58,7 @@ func (tv *timeValidator) Validate(n interface{}, fieldName string, fieldKind ref
 		if value > *tv.Naf {
 			nafString := time.Unix(*tv.Naf, 0).UTC().String()
 			valueString := time.Unix(value, 0).UTC().String()
-			errorMessage := fmt.Sprintf(timeNotAfterErrorTemplate, fieldName, valueString, nafString)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeMax, fieldName, strconv.FormatInt(*tv.Naf, 10), n, "").WithDetail("time", valueString).WithDetail("limit", nafString)
 		}
 	}
 
@@ -81,20 +67,22 @@ func (tv *timeValidator) Validate(n interface{}, fieldName string, fieldKind ref
 
 func (tv *timeValidator) ReadOptionsFromTagItems(items []string) error {
 	for i := 0; i < len(items); i++ {
-		switch parts := strings.Split(items[i], "="); parts[0] {
+		option, err := validator.ParseOption(items[i])
+		if err != nil {
+			return err
+		}
+		switch option.Key {
 		case "allowint":
 			tv.AllowInt = true
-		case "required":
-			tv.Required = true
 		case "nbf":
-			value, err := strconv.ParseInt(parts[1], 0, 64)
+			value, err := strconv.ParseInt(option.Value, 0, 64)
 			if err != nil {
 				errorString := fmt.Sprintf("timeValidator tag nbf value invalid: %s", err.Error())
 				return errors.New(errorString)
 			}
 			tv.Nbf = &value
 		case "naf":
-			value, err := strconv.ParseInt(parts[1], 0, 64)
+			value, err := strconv.ParseInt(option.Value, 0, 64)
 			if err != nil {
 				errorString := fmt.Sprintf("timeValidator tag naf value invalid: %s", err.Error())
 				return errors.New(errorString)
@@ -104,3 +92,20 @@ func (tv *timeValidator) ReadOptionsFromTagItems(items []string) error {
 	}
 	return nil
 }
+
+// Options returns the options the time validator accepts in tag data.
+func (tv *timeValidator) Options() []validator.OptionSpec {
+	return []validator.OptionSpec{
+		{Key: "allowint", Description: "allow int64 unix timestamps", Flag: true},
+		{Key: "nbf", Description: "the unix timestamp the time must not be before"},
+		{Key: "naf", Description: "the unix timestamp the time must not be after"},
+	}
+}
+
+// CheckType returns an error unless the type is time.Time, or int64 when the allowint option is set.
+func (tv *timeValidator) CheckType(t reflect.Type) error {
+	if tv.AllowInt {
+		return validator.CheckTypeIs(validatorName, t, reflect.TypeOf(time.Time{}), reflect.TypeOf(int64(0)))
+	}
+	return validator.CheckTypeIs(validatorName, t, reflect.TypeOf(time.Time{}))
+}
@@ -13,7 +13,6 @@ func TestValidTimeValue(t *testing.T) {
 		AllowInt: true,
 		Naf:      &naf,
 		Nbf:      &nbf,
-		Required: true,
 	}
 	testValue := time.Unix(1618968930, 0)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -29,7 +28,6 @@ func TestValidInt64Value(t *testing.T) {
 		AllowInt: true,
 		Naf:      &naf,
 		Nbf:      &nbf,
-		Required: true,
 	}
 	testValue := int64(1618968930)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -44,7 +42,6 @@ func TestValidUintValueNoNotBefore(t *testing.T) {
 	tValidator := timeValidator{
 		AllowInt: true,
 		Naf:      &naf,
-		Required: true,
 	}
 	testValue := int64(1618968930)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -59,7 +56,6 @@ func TestValidUintValueNoNotAfter(t *testing.T) {
 	tValidator := timeValidator{
 		AllowInt: true,
 		Nbf:      &nbf,
-		Required: true,
 	}
 	testValue := int64(1618968930)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -75,7 +71,6 @@ func TestValidNotBeforeInclusive(t *testing.T) {
 		AllowInt: true,
 		Naf:      &naf,
 		Nbf:      &nbf,
-		Required: true,
 	}
 	testValue := int64(1618968920)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -91,7 +86,6 @@ func TestValidNotAfterInclusive(t *testing.T) {
 		AllowInt: true,
 		Naf:      &naf,
 		Nbf:      &nbf,
-		Required: true,
 	}
 	testValue := int64(1618968940)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -102,14 +96,12 @@ func TestValidNotAfterInclusive(t *testing.T) {
 }
 
 func TestValidValidNotRequired(t *testing.T) {
-	tValidator := timeValidator{
-		Required: false,
-	}
+	tValidator := timeValidator{}
 	testValue := time.Time{}
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
 	if !isValid {
-		t.Error("isValid should be true because Required is false, and a default time value is provided: ", err.Error())
+		t.Error("isValid should be true because a default time value is provided: ", err.Error())
 	}
 }
 
@@ -119,7 +111,6 @@ func TestValidInvalidNotBefore(t *testing.T) {
 		AllowInt: true,
 		Naf:      &naf,
 		Nbf:      &nbf,
-		Required: true,
 	}
 	testValue := int64(1618968930)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -140,7 +131,6 @@ func TestValidInvalidNotAfter(t *testing.T) {
 		AllowInt: true,
 		Naf:      &naf,
 		Nbf:      &nbf,
-		Required: true,
 	}
 	testValue := int64(1618968940)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -161,7 +151,6 @@ func TestValidInvalidAllowInt(t *testing.T) {
 		AllowInt: false,
 		Naf:      &naf,
 		Nbf:      &nbf,
-		Required: true,
 	}
 	testValue := int64(1618968930)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -176,34 +165,12 @@ func TestValidInvalidAllowInt(t *testing.T) {
 	}
 }
 
-func TestValidInvalidRequired(t *testing.T) {
-	nbf, naf := int64(1618968920), int64(1618968940)
-	tValidator := timeValidator{
-		AllowInt: true,
-		Naf:      &naf,
-		Nbf:      &nbf,
-		Required: true,
-	}
-	testValue := time.Time{}
-	valueKind := reflect.TypeOf(testValue).Kind()
-	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
-	expectedErrorPrefix := "required:"
-	if isValid {
-		t.Error("isValid should be false because Required is true, and a default time value is provided")
-	} else if err == nil {
-		t.Error("err should be populated")
-	} else if hasRightIndex := strings.Index(err.Error(), expectedErrorPrefix); hasRightIndex == -1 {
-		t.Errorf("err.Error() begin with '%s': %s", expectedErrorPrefix, err.Error())
-	}
-}
-
 func TestValidInvalidType(t *testing.T) {
 	nbf, naf := int64(1618968920), int64(1618968940)
 	tValidator := timeValidator{
 		AllowInt: true,
 		Naf:      &naf,
 		Nbf:      &nbf,
-		Required: true,
 	}
 	testValue := float32(1618968930)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -227,9 +194,6 @@ func TestReadOptionsFromTagItemsAllParameters(t *testing.T) {
 	if tValidator.AllowInt != true {
 		t.Error("AllowInt should be true")
 	}
-	if tValidator.Required != true {
-		t.Error("Required should be true")
-	}
 	if tValidator.Nbf == nil || *tValidator.Nbf != 1618968920 {
 		t.Error("*Nbf should be 1618968920")
 	}
@@ -5,14 +5,13 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
-	"strings"
 
 	"github.com/calvine/simplevalidation/validator"
 )
 
 const (
-	numberMinValueErrorTemplate = "min: the field %s value %d is less than the minimum value %d"
-	numberMaxValueErrorTemplate = "max: the field %s value %d is greater than the maximum value %d"
+	// validatorName is the name the uint validator is registered under in the validation package.
+	validatorName = "uint"
 )
 
 // uint validator
@@ -30,14 +29,12 @@ func New() validator.Validator {
 func validateUint(i uint64, min, max *uint64, name string) (bool, error) {
 	if min != nil {
 		if i < *min {
-			errorMessage := fmt.Sprintf(numberMinValueErrorTemplate, name, i, *min)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeMin, name, strconv.FormatUint(*min, 10), i, "")
 		}
 	}
 	if max != nil {
 		if i > *max {
-			errorMessage := fmt.Sprintf(numberMaxValueErrorTemplate, name, i, *max)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeMax, name, strconv.FormatUint(*max, 10), i, "")
 		}
 	}
 	return true, nil
@@ -58,8 +55,7 @@ func (nv *uintValidator) Validate(n interface{}, fieldName string, fieldKind ref
 		var ok bool
 		value, ok = n.(uint64)
 		if !ok {
-			errorMessage := fmt.Sprintf(validator.InvalidTypeErrorTemplate, fieldName, t)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeType, fieldName, "", n, "")
 		}
 	}
 	return validateUint(value, nv.Min, nv.Max, fieldName)
@@ -67,16 +63,20 @@ func (nv *uintValidator) Validate(n interface{}, fieldName string, fieldKind ref
 
 func (nv *uintValidator) ReadOptionsFromTagItems(items []string) error {
 	for i := 0; i < len(items); i++ {
-		switch parts := strings.Split(items[i], "="); parts[0] {
+		option, err := validator.ParseOption(items[i])
+		if err != nil {
+			return err
+		}
+		switch option.Key {
 		case "min":
-			min, err := strconv.ParseUint(parts[1], 0, 64)
+			min, err := strconv.ParseUint(option.Value, 0, 64)
 			if err != nil {
 				errorString := fmt.Sprintf("uintValidator tag min value invalid: %s", err.Error())
 				return errors.New(errorString)
 			}
 			nv.Min = &min
 		case "max":
-			max, err := strconv.ParseUint(parts[1], 0, 64)
+			max, err := strconv.ParseUint(option.Value, 0, 64)
 			if err != nil {
 				errorString := fmt.Sprintf("uintValidator tag max value invalid: %s", err.Error())
 				return errors.New(errorString)
@@ -86,3 +86,16 @@ func (nv *uintValidator) ReadOptionsFromTagItems(items []string) error {
 	}
 	return nil
 }
+
+// Options returns the options the uint validator accepts in tag data.
+func (nv *uintValidator) Options() []validator.OptionSpec {
+	return []validator.OptionSpec{
+		{Key: "min", Description: "the minimum value allowed"},
+		{Key: "max", Description: "the maximum value allowed"},
+	}
+}
+
+// CheckType returns an error unless the type is one of the unsigned integer types.
+func (nv *uintValidator) CheckType(t reflect.Type) error {
+	return validator.CheckTypeIs(validatorName, t, reflect.TypeOf(uint(0)), reflect.TypeOf(uint8(0)), reflect.TypeOf(uint16(0)), reflect.TypeOf(uint32(0)), reflect.TypeOf(uint64(0)))
+}
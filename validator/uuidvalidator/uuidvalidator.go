@@ -1,10 +1,7 @@
 package uuidvalidator
 
 import (
-	"errors"
-	"fmt"
 	"reflect"
-	"strings"
 
 	"github.com/calvine/simplevalidation/validator"
 	"github.com/google/uuid"
@@ -16,15 +13,15 @@ type uuidValidator struct {
 	AllowEmptyUUID bool
 	// AllowString allows strings to be parsed into uuids for validation, if false a type validation error will be returned.
 	AllowString bool
-	// Required really only checks if a string is passed in as an empty string.
-	Required bool
 }
 
 const (
-	uuidStringProvidedErrorTemplate = "type: The field %s is a string, but allowstring was not provided in the validation tag"
-	uuidInvalidStringErrorTemplate  = "invalid: the field %s has the value %s which could not be parsed into a UUID"
-	uuidNoEmptyUUIDErrorTemplate    = "no empty: the field %s has an empty uuid value, but AllowEmptyUUID is false"
-	uuidNoValueErrorTemplate        = "required: The field %s had an empty value: %v"
+	// validatorName is the name the uuid validator is registered under in the validation package.
+	validatorName = "uuid"
+	// allowStringKey is the catalog key for the error when a string is provided but allowstring is not set.
+	allowStringKey = "uuid.allowstring"
+	// allowEmptyUUIDKey is the catalog key for the error when an empty uuid is provided but allowemptyuuid is not set.
+	allowEmptyUUIDKey = "uuid.allowemptyuuid"
 )
 
 func New() validator.Validator {
@@ -34,51 +31,36 @@ func New() validator.Validator {
 
 func (uv *uuidValidator) Validate(n interface{}, fieldName string, fieldKind reflect.Kind) (bool, error) {
 	var value uuid.UUID
-	var stringValue string
 	var parseError error
 	emptyUUID := uuid.UUID{}
-	noValueProvided := false
 	emptyUUIDProvided := false
 	switch t := n.(type) {
 	case string:
 		if !uv.AllowString {
-			errorMessage := fmt.Sprintf(uuidStringProvidedErrorTemplate, fieldName)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeType, fieldName, "", n, "").WithKey(allowStringKey)
 		}
-		stringValue = t
-		if stringValue == "" {
-			noValueProvided = true
-		} else {
-			value, parseError = uuid.Parse(t)
-			if value == emptyUUID && parseError == nil {
-				emptyUUIDProvided = true
-			}
+		// an empty string is not a valid uuid, use the omitempty option to allow it.
+		value, parseError = uuid.Parse(t)
+		if value == emptyUUID && parseError == nil {
+			emptyUUIDProvided = true
 		}
 	default:
 		var ok bool
 		value, ok = t.(uuid.UUID)
 		if !ok {
-			errorMessage := fmt.Sprintf(validator.InvalidTypeErrorTemplate, fieldName, t)
-			return false, errors.New(errorMessage)
+			return false, validator.NewFieldError(validatorName, validator.CodeType, fieldName, "", n, "")
 		}
 		if t == emptyUUID {
 			emptyUUIDProvided = true
 		}
 	}
 
-	if uv.Required && noValueProvided {
-		errorMessage := fmt.Sprintf(uuidNoValueErrorTemplate, fieldName, value)
-		return false, errors.New(errorMessage)
-	}
-
 	if !uv.AllowEmptyUUID && emptyUUIDProvided {
-		errorMessage := fmt.Sprintf(uuidNoEmptyUUIDErrorTemplate, fieldName)
-		return false, errors.New(errorMessage)
+		return false, validator.NewFieldError(validatorName, validator.CodeInvalid, fieldName, "", n, "").WithKey(allowEmptyUUIDKey)
 	}
 
 	if parseError != nil {
-		errorMessage := fmt.Sprintf(uuidInvalidStringErrorTemplate, fieldName, stringValue)
-		return false, errors.New(errorMessage)
+		return false, validator.NewFieldError(validatorName, validator.CodeInvalid, fieldName, "", n, "")
 	}
 	return true, nil
 
@@ -86,9 +68,11 @@ func (uv *uuidValidator) Validate(n interface{}, fieldName string, fieldKind ref
 
 func (uv *uuidValidator) ReadOptionsFromTagItems(items []string) error {
 	for i := 0; i < len(items); i++ {
-		switch parts := strings.Split(items[i], "="); parts[0] {
-		case "required":
-			uv.Required = true
+		option, err := validator.ParseOption(items[i])
+		if err != nil {
+			return err
+		}
+		switch option.Key {
 		case "allowemptyuuid":
 			uv.AllowEmptyUUID = true
 		case "allowstring":
@@ -97,3 +81,19 @@ func (uv *uuidValidator) ReadOptionsFromTagItems(items []string) error {
 	}
 	return nil
 }
+
+// Options returns the options the uuid validator accepts in tag data.
+func (uv *uuidValidator) Options() []validator.OptionSpec {
+	return []validator.OptionSpec{
+		{Key: "allowemptyuuid", Description: "allow the empty uuid", Flag: true, AllowsZero: true},
+		{Key: "allowstring", Description: "allow uuids in strings", Flag: true},
+	}
+}
+
+// CheckType returns an error unless the type is uuid.UUID, or string when the allowstring option is set.
+func (uv *uuidValidator) CheckType(t reflect.Type) error {
+	if uv.AllowString {
+		return validator.CheckTypeIs(validatorName, t, reflect.TypeOf(uuid.UUID{}), reflect.TypeOf(""))
+	}
+	return validator.CheckTypeIs(validatorName, t, reflect.TypeOf(uuid.UUID{}))
+}
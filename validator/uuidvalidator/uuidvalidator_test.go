@@ -1,17 +1,17 @@
 package uuidvalidator
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/calvine/simplevalidation/validator"
 	"github.com/google/uuid"
 )
 
 func TestValidUUID(t *testing.T) {
-	tValidator := uuidValidator{
-		Required: true,
-	}
+	tValidator := uuidValidator{}
 	testValue := uuid.New()
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
@@ -23,7 +23,6 @@ func TestValidUUID(t *testing.T) {
 func TestValidEmptyUUID(t *testing.T) {
 	tValidator := uuidValidator{
 		AllowEmptyUUID: true,
-		Required:       true,
 	}
 	testValue := uuid.UUID{}
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -36,7 +35,6 @@ func TestValidEmptyUUID(t *testing.T) {
 func TestValidUUIDString(t *testing.T) {
 	tValidator := uuidValidator{
 		AllowString: true,
-		Required:    false,
 	}
 	testValue := uuid.New().String()
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -50,7 +48,6 @@ func TestValidEmptyUUIDString(t *testing.T) {
 	tValidator := uuidValidator{
 		AllowEmptyUUID: true,
 		AllowString:    true,
-		Required:       true,
 	}
 	testValue := uuid.UUID{}.String()
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -60,23 +57,23 @@ func TestValidEmptyUUIDString(t *testing.T) {
 	}
 }
 
-func TestValidNotRerquiedEmptyString(t *testing.T) {
+func TestInvalidEmptyString(t *testing.T) {
 	tValidator := uuidValidator{
 		AllowString: true,
-		Required:    false,
 	}
 	testValue := ""
 	valueKind := reflect.TypeOf(testValue).Kind()
 	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
-	if !isValid {
-		t.Error("isValid should be true because Required is false and AllowString is true, and an empty string is provided: ", err.Error())
+	if isValid {
+		t.Error("isValid should be false because an empty string is not a valid uuid, the omitempty option allows it instead")
+	} else if !errors.Is(err, validator.ErrInvalidFormat) {
+		t.Errorf("err should wrap validator.ErrInvalidFormat: %v", err)
 	}
 }
 
 func TestInvalidAllowString(t *testing.T) {
 	tValidator := uuidValidator{
 		AllowString: false,
-		Required:    true,
 	}
 	testValue := uuid.New().String()
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -94,7 +91,6 @@ func TestInvalidAllowString(t *testing.T) {
 func TestInvalidAllowEmptyUUID(t *testing.T) {
 	tValidator := uuidValidator{
 		AllowEmptyUUID: false,
-		Required:       true,
 	}
 	testValue := uuid.UUID{}
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -109,28 +105,9 @@ func TestInvalidAllowEmptyUUID(t *testing.T) {
 	}
 }
 
-func TestInvalidRequiredAllowString(t *testing.T) {
-	tValidator := uuidValidator{
-		AllowString: true,
-		Required:    true,
-	}
-	testValue := ""
-	valueKind := reflect.TypeOf(testValue).Kind()
-	isValid, err := tValidator.Validate(testValue, "testValue", valueKind)
-	expectedErrorPrefix := "required:"
-	if isValid {
-		t.Error("isValid should be false because the value being validated is an empty string")
-	} else if err == nil {
-		t.Error("err should be populated")
-	} else if hasRightIndex := strings.Index(err.Error(), expectedErrorPrefix); hasRightIndex == -1 {
-		t.Errorf("err.Error() begin with '%s': %s", expectedErrorPrefix, err.Error())
-	}
-}
-
 func TestInvalidStringUUIDFormat(t *testing.T) {
 	tValidator := uuidValidator{
 		AllowString: true,
-		Required:    true,
 	}
 	testValue := "not a uuid..."
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -148,7 +125,6 @@ func TestInvalidStringUUIDFormat(t *testing.T) {
 func TestInvalidType(t *testing.T) {
 	tValidator := uuidValidator{
 		AllowString: true,
-		Required:    true,
 	}
 	testValue := uint(32)
 	valueKind := reflect.TypeOf(testValue).Kind()
@@ -169,9 +145,6 @@ func TestReadOptionsFromTagItemsAllParameters(t *testing.T) {
 	tagArgs := strings.Split(testTag, ",")
 	tValidator := uuidValidator{}
 	tValidator.ReadOptionsFromTagItems(tagArgs[1:])
-	if tValidator.Required != true {
-		t.Error("Required should be true")
-	}
 	if tValidator.AllowEmptyUUID != true {
 		t.Error("AllowEmptyUUID should be true")
 	}
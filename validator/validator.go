@@ -1,8 +1,12 @@
 package validator
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+)
 
 const (
+	// InvalidTypeErrorTemplate is a fmt template for type errors, kept for custom validators. The built in validators render their messages from the English Catalog instead.
 	InvalidTypeErrorTemplate = "type: the value of %s is of type %T which is not valid"
 )
 
@@ -10,6 +14,7 @@ const (
 type Validator interface {
 	// Validate takes a value, fieldName if from struct, and the fieldKind and returns true if valid and false if not.
 	// If the value is not valid then an error should also be returned with info on why its invalid.
+	// A validator built from struct tag data is cached and reused for every value of that struct type, so Validate must not modify the validator and must be safe to call from multiple goroutines.
 	Validate(value interface{}, fieldName string, fieldKind reflect.Kind) (bool, error)
 	/*
 		ReadOptionsFromTagItems takes in an array of tag arguments and reads them into the validator.
@@ -26,5 +31,14 @@ type Validator interface {
 	ReadOptionsFromTagItems([]string) error
 }
 
+// ContextValidator is an optional interface for validators that do work which should respect cancellation and deadlines, like network lookups.
+//
+// When a validator implements ContextValidator the validation package calls ValidateContext with the context passed to the validation functions instead of calling Validate.
+type ContextValidator interface {
+	Validator
+	// ValidateContext behaves like Validate, but stops any long running work when the context is done.
+	ValidateContext(ctx context.Context, value interface{}, fieldName string, fieldKind reflect.Kind) (bool, error)
+}
+
 // ValidatorFactory is a type alias for a function that take no parameters and reutrns a Validator.
 type ValidatorFactory func() Validator